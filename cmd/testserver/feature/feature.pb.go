@@ -0,0 +1,950 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: cmd/testserver/feature/feature.proto
+
+package featurev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TickerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// number of ticks to send
+	Count uint32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// time between ticks, 100ms when unset
+	Interval      *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TickerRequest) Reset() {
+	*x = TickerRequest{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TickerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TickerRequest) ProtoMessage() {}
+
+func (x *TickerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TickerRequest.ProtoReflect.Descriptor instead.
+func (*TickerRequest) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TickerRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *TickerRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+type Tick struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           uint32                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tick) Reset() {
+	*x = Tick{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tick) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tick) ProtoMessage() {}
+
+func (x *Tick) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tick.ProtoReflect.Descriptor instead.
+func (*Tick) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Tick) GetSeq() uint32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Tick) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type SumRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SumRequest) Reset() {
+	*x = SumRequest{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SumRequest) ProtoMessage() {}
+
+func (x *SumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SumRequest.ProtoReflect.Descriptor instead.
+func (*SumRequest) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SumRequest) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *SumRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type SumSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         uint32                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Sum           int64                  `protobuf:"varint,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	Min           int64                  `protobuf:"varint,3,opt,name=min,proto3" json:"min,omitempty"`
+	Max           int64                  `protobuf:"varint,4,opt,name=max,proto3" json:"max,omitempty"`
+	Mean          float64                `protobuf:"fixed64,5,opt,name=mean,proto3" json:"mean,omitempty"`
+	Labels        []string               `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SumSummary) Reset() {
+	*x = SumSummary{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SumSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SumSummary) ProtoMessage() {}
+
+func (x *SumSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SumSummary.ProtoReflect.Descriptor instead.
+func (*SumSummary) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SumSummary) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SumSummary) GetSum() int64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *SumSummary) GetMin() int64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *SumSummary) GetMax() int64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *SumSummary) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *SumSummary) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type FailRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// google.rpc.Code to fail with, INVALID_ARGUMENT (3) when unset
+	Code          int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailRequest) Reset() {
+	*x = FailRequest{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailRequest) ProtoMessage() {}
+
+func (x *FailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailRequest.ProtoReflect.Descriptor instead.
+func (*FailRequest) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FailRequest) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *FailRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type MetadataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// headers to send back as response headers
+	Headers map[string]string `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// trailers to send back as response trailers
+	Trailers      map[string]string `protobuf:"bytes,2,rep,name=trailers,proto3" json:"trailers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataRequest) Reset() {
+	*x = MetadataRequest{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataRequest) ProtoMessage() {}
+
+func (x *MetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataRequest.ProtoReflect.Descriptor instead.
+func (*MetadataRequest) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MetadataRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *MetadataRequest) GetTrailers() map[string]string {
+	if x != nil {
+		return x.Trailers
+	}
+	return nil
+}
+
+type MetadataReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// request metadata as received by the server
+	Received      map[string]string `protobuf:"bytes,1,rep,name=received,proto3" json:"received,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataReply) Reset() {
+	*x = MetadataReply{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataReply) ProtoMessage() {}
+
+func (x *MetadataReply) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataReply.ProtoReflect.Descriptor instead.
+func (*MetadataReply) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MetadataReply) GetReceived() map[string]string {
+	if x != nil {
+		return x.Received
+	}
+	return nil
+}
+
+type SlowRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// how long to wait before replying
+	Delay         *durationpb.Duration `protobuf:"bytes,1,opt,name=delay,proto3" json:"delay,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SlowRequest) Reset() {
+	*x = SlowRequest{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SlowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlowRequest) ProtoMessage() {}
+
+func (x *SlowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlowRequest.ProtoReflect.Descriptor instead.
+func (*SlowRequest) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SlowRequest) GetDelay() *durationpb.Duration {
+	if x != nil {
+		return x.Delay
+	}
+	return nil
+}
+
+type SlowReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Waited        *durationpb.Duration   `protobuf:"bytes,1,opt,name=waited,proto3" json:"waited,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SlowReply) Reset() {
+	*x = SlowReply{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SlowReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlowReply) ProtoMessage() {}
+
+func (x *SlowReply) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlowReply.ProtoReflect.Descriptor instead.
+func (*SlowReply) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SlowReply) GetWaited() *durationpb.Duration {
+	if x != nil {
+		return x.Waited
+	}
+	return nil
+}
+
+// TreeNode is a recursive message.
+type TreeNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Children      []*TreeNode            `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TreeNode) Reset() {
+	*x = TreeNode{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TreeNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeNode) ProtoMessage() {}
+
+func (x *TreeNode) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeNode.ProtoReflect.Descriptor instead.
+func (*TreeNode) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TreeNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TreeNode) GetChildren() []*TreeNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type TreeSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         uint32                 `protobuf:"varint,1,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	Depth         uint32                 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TreeSummary) Reset() {
+	*x = TreeSummary{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TreeSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeSummary) ProtoMessage() {}
+
+func (x *TreeSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeSummary.ProtoReflect.Descriptor instead.
+func (*TreeSummary) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TreeSummary) GetNodes() uint32 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *TreeSummary) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type WellKnownMessage struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Timestamp     *timestamppb.Timestamp  `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Duration      *durationpb.Duration    `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	Struct        *structpb.Struct        `protobuf:"bytes,3,opt,name=struct,proto3" json:"struct,omitempty"`
+	Value         *structpb.Value         `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	ListValue     *structpb.ListValue     `protobuf:"bytes,5,opt,name=list_value,json=listValue,proto3" json:"list_value,omitempty"`
+	Any           *anypb.Any              `protobuf:"bytes,6,opt,name=any,proto3" json:"any,omitempty"`
+	FieldMask     *fieldmaskpb.FieldMask  `protobuf:"bytes,7,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"`
+	Empty         *emptypb.Empty          `protobuf:"bytes,8,opt,name=empty,proto3" json:"empty,omitempty"`
+	StringValue   *wrapperspb.StringValue `protobuf:"bytes,9,opt,name=string_value,json=stringValue,proto3" json:"string_value,omitempty"`
+	Int64Value    *wrapperspb.Int64Value  `protobuf:"bytes,10,opt,name=int64_value,json=int64Value,proto3" json:"int64_value,omitempty"`
+	BoolValue     *wrapperspb.BoolValue   `protobuf:"bytes,11,opt,name=bool_value,json=boolValue,proto3" json:"bool_value,omitempty"`
+	DoubleValue   *wrapperspb.DoubleValue `protobuf:"bytes,12,opt,name=double_value,json=doubleValue,proto3" json:"double_value,omitempty"`
+	BytesValue    *wrapperspb.BytesValue  `protobuf:"bytes,13,opt,name=bytes_value,json=bytesValue,proto3" json:"bytes_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WellKnownMessage) Reset() {
+	*x = WellKnownMessage{}
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WellKnownMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WellKnownMessage) ProtoMessage() {}
+
+func (x *WellKnownMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_feature_feature_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WellKnownMessage.ProtoReflect.Descriptor instead.
+func (*WellKnownMessage) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_feature_feature_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WellKnownMessage) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetStruct() *structpb.Struct {
+	if x != nil {
+		return x.Struct
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetListValue() *structpb.ListValue {
+	if x != nil {
+		return x.ListValue
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetAny() *anypb.Any {
+	if x != nil {
+		return x.Any
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetFieldMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.FieldMask
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetEmpty() *emptypb.Empty {
+	if x != nil {
+		return x.Empty
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetStringValue() *wrapperspb.StringValue {
+	if x != nil {
+		return x.StringValue
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetInt64Value() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.Int64Value
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetBoolValue() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.BoolValue
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetDoubleValue() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.DoubleValue
+	}
+	return nil
+}
+
+func (x *WellKnownMessage) GetBytesValue() *wrapperspb.BytesValue {
+	if x != nil {
+		return x.BytesValue
+	}
+	return nil
+}
+
+var File_cmd_testserver_feature_feature_proto protoreflect.FileDescriptor
+
+const file_cmd_testserver_feature_feature_proto_rawDesc = "" +
+	"\n" +
+	"$cmd/testserver/feature/feature.proto\x12\n" +
+	"feature.v1\x1a\x19google/protobuf/any.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\"\\\n" +
+	"\rTickerRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\rR\x05count\x125\n" +
+	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\"H\n" +
+	"\x04Tick\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\rR\x03seq\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"8\n" +
+	"\n" +
+	"SumRequest\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\"\x84\x01\n" +
+	"\n" +
+	"SumSummary\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\rR\x05count\x12\x10\n" +
+	"\x03sum\x18\x02 \x01(\x03R\x03sum\x12\x10\n" +
+	"\x03min\x18\x03 \x01(\x03R\x03min\x12\x10\n" +
+	"\x03max\x18\x04 \x01(\x03R\x03max\x12\x12\n" +
+	"\x04mean\x18\x05 \x01(\x01R\x04mean\x12\x16\n" +
+	"\x06labels\x18\x06 \x03(\tR\x06labels\";\n" +
+	"\vFailRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x95\x02\n" +
+	"\x0fMetadataRequest\x12B\n" +
+	"\aheaders\x18\x01 \x03(\v2(.feature.v1.MetadataRequest.HeadersEntryR\aheaders\x12E\n" +
+	"\btrailers\x18\x02 \x03(\v2).feature.v1.MetadataRequest.TrailersEntryR\btrailers\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
+	"\rTrailersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x91\x01\n" +
+	"\rMetadataReply\x12C\n" +
+	"\breceived\x18\x01 \x03(\v2'.feature.v1.MetadataReply.ReceivedEntryR\breceived\x1a;\n" +
+	"\rReceivedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
+	"\vSlowRequest\x12/\n" +
+	"\x05delay\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x05delay\">\n" +
+	"\tSlowReply\x121\n" +
+	"\x06waited\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x06waited\"P\n" +
+	"\bTreeNode\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x120\n" +
+	"\bchildren\x18\x02 \x03(\v2\x14.feature.v1.TreeNodeR\bchildren\"9\n" +
+	"\vTreeSummary\x12\x14\n" +
+	"\x05nodes\x18\x01 \x01(\rR\x05nodes\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\rR\x05depth\"\xe7\x05\n" +
+	"\x10WellKnownMessage\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12/\n" +
+	"\x06struct\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x06struct\x12,\n" +
+	"\x05value\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\x05value\x129\n" +
+	"\n" +
+	"list_value\x18\x05 \x01(\v2\x1a.google.protobuf.ListValueR\tlistValue\x12&\n" +
+	"\x03any\x18\x06 \x01(\v2\x14.google.protobuf.AnyR\x03any\x129\n" +
+	"\n" +
+	"field_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\tfieldMask\x12,\n" +
+	"\x05empty\x18\b \x01(\v2\x16.google.protobuf.EmptyR\x05empty\x12?\n" +
+	"\fstring_value\x18\t \x01(\v2\x1c.google.protobuf.StringValueR\vstringValue\x12<\n" +
+	"\vint64_value\x18\n" +
+	" \x01(\v2\x1b.google.protobuf.Int64ValueR\n" +
+	"int64Value\x129\n" +
+	"\n" +
+	"bool_value\x18\v \x01(\v2\x1a.google.protobuf.BoolValueR\tboolValue\x12?\n" +
+	"\fdouble_value\x18\f \x01(\v2\x1c.google.protobuf.DoubleValueR\vdoubleValue\x12<\n" +
+	"\vbytes_value\x18\r \x01(\v2\x1b.google.protobuf.BytesValueR\n" +
+	"bytesValue2\xbc\x03\n" +
+	"\x0eFeatureService\x127\n" +
+	"\x06Ticker\x12\x19.feature.v1.TickerRequest\x1a\x10.feature.v1.Tick0\x01\x127\n" +
+	"\x03Sum\x12\x16.feature.v1.SumRequest\x1a\x16.feature.v1.SumSummary(\x01\x127\n" +
+	"\x04Fail\x12\x17.feature.v1.FailRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\bMetadata\x12\x1b.feature.v1.MetadataRequest\x1a\x19.feature.v1.MetadataReply\x126\n" +
+	"\x04Slow\x12\x17.feature.v1.SlowRequest\x1a\x15.feature.v1.SlowReply\x125\n" +
+	"\x04Tree\x12\x14.feature.v1.TreeNode\x1a\x17.feature.v1.TreeSummary\x12L\n" +
+	"\x0eWellKnownTypes\x12\x1c.feature.v1.WellKnownMessage\x1a\x1c.feature.v1.WellKnownMessageB\xb2\x01\n" +
+	"\x0ecom.feature.v1B\fFeatureProtoP\x01ZIgithub.com/prnvbn/grpcexp/cmd/testserver/cmd/testserver/feature;featurev1\xa2\x02\x03FXX\xaa\x02\n" +
+	"Feature.V1\xca\x02\n" +
+	"Feature\\V1\xe2\x02\x16Feature\\V1\\GPBMetadata\xea\x02\vFeature::V1b\x06proto3"
+
+var (
+	file_cmd_testserver_feature_feature_proto_rawDescOnce sync.Once
+	file_cmd_testserver_feature_feature_proto_rawDescData []byte
+)
+
+func file_cmd_testserver_feature_feature_proto_rawDescGZIP() []byte {
+	file_cmd_testserver_feature_feature_proto_rawDescOnce.Do(func() {
+		file_cmd_testserver_feature_feature_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cmd_testserver_feature_feature_proto_rawDesc), len(file_cmd_testserver_feature_feature_proto_rawDesc)))
+	})
+	return file_cmd_testserver_feature_feature_proto_rawDescData
+}
+
+var file_cmd_testserver_feature_feature_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_cmd_testserver_feature_feature_proto_goTypes = []any{
+	(*TickerRequest)(nil),          // 0: feature.v1.TickerRequest
+	(*Tick)(nil),                   // 1: feature.v1.Tick
+	(*SumRequest)(nil),             // 2: feature.v1.SumRequest
+	(*SumSummary)(nil),             // 3: feature.v1.SumSummary
+	(*FailRequest)(nil),            // 4: feature.v1.FailRequest
+	(*MetadataRequest)(nil),        // 5: feature.v1.MetadataRequest
+	(*MetadataReply)(nil),          // 6: feature.v1.MetadataReply
+	(*SlowRequest)(nil),            // 7: feature.v1.SlowRequest
+	(*SlowReply)(nil),              // 8: feature.v1.SlowReply
+	(*TreeNode)(nil),               // 9: feature.v1.TreeNode
+	(*TreeSummary)(nil),            // 10: feature.v1.TreeSummary
+	(*WellKnownMessage)(nil),       // 11: feature.v1.WellKnownMessage
+	nil,                            // 12: feature.v1.MetadataRequest.HeadersEntry
+	nil,                            // 13: feature.v1.MetadataRequest.TrailersEntry
+	nil,                            // 14: feature.v1.MetadataReply.ReceivedEntry
+	(*durationpb.Duration)(nil),    // 15: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),  // 16: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),        // 17: google.protobuf.Struct
+	(*structpb.Value)(nil),         // 18: google.protobuf.Value
+	(*structpb.ListValue)(nil),     // 19: google.protobuf.ListValue
+	(*anypb.Any)(nil),              // 20: google.protobuf.Any
+	(*fieldmaskpb.FieldMask)(nil),  // 21: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),          // 22: google.protobuf.Empty
+	(*wrapperspb.StringValue)(nil), // 23: google.protobuf.StringValue
+	(*wrapperspb.Int64Value)(nil),  // 24: google.protobuf.Int64Value
+	(*wrapperspb.BoolValue)(nil),   // 25: google.protobuf.BoolValue
+	(*wrapperspb.DoubleValue)(nil), // 26: google.protobuf.DoubleValue
+	(*wrapperspb.BytesValue)(nil),  // 27: google.protobuf.BytesValue
+}
+var file_cmd_testserver_feature_feature_proto_depIdxs = []int32{
+	15, // 0: feature.v1.TickerRequest.interval:type_name -> google.protobuf.Duration
+	16, // 1: feature.v1.Tick.time:type_name -> google.protobuf.Timestamp
+	12, // 2: feature.v1.MetadataRequest.headers:type_name -> feature.v1.MetadataRequest.HeadersEntry
+	13, // 3: feature.v1.MetadataRequest.trailers:type_name -> feature.v1.MetadataRequest.TrailersEntry
+	14, // 4: feature.v1.MetadataReply.received:type_name -> feature.v1.MetadataReply.ReceivedEntry
+	15, // 5: feature.v1.SlowRequest.delay:type_name -> google.protobuf.Duration
+	15, // 6: feature.v1.SlowReply.waited:type_name -> google.protobuf.Duration
+	9,  // 7: feature.v1.TreeNode.children:type_name -> feature.v1.TreeNode
+	16, // 8: feature.v1.WellKnownMessage.timestamp:type_name -> google.protobuf.Timestamp
+	15, // 9: feature.v1.WellKnownMessage.duration:type_name -> google.protobuf.Duration
+	17, // 10: feature.v1.WellKnownMessage.struct:type_name -> google.protobuf.Struct
+	18, // 11: feature.v1.WellKnownMessage.value:type_name -> google.protobuf.Value
+	19, // 12: feature.v1.WellKnownMessage.list_value:type_name -> google.protobuf.ListValue
+	20, // 13: feature.v1.WellKnownMessage.any:type_name -> google.protobuf.Any
+	21, // 14: feature.v1.WellKnownMessage.field_mask:type_name -> google.protobuf.FieldMask
+	22, // 15: feature.v1.WellKnownMessage.empty:type_name -> google.protobuf.Empty
+	23, // 16: feature.v1.WellKnownMessage.string_value:type_name -> google.protobuf.StringValue
+	24, // 17: feature.v1.WellKnownMessage.int64_value:type_name -> google.protobuf.Int64Value
+	25, // 18: feature.v1.WellKnownMessage.bool_value:type_name -> google.protobuf.BoolValue
+	26, // 19: feature.v1.WellKnownMessage.double_value:type_name -> google.protobuf.DoubleValue
+	27, // 20: feature.v1.WellKnownMessage.bytes_value:type_name -> google.protobuf.BytesValue
+	0,  // 21: feature.v1.FeatureService.Ticker:input_type -> feature.v1.TickerRequest
+	2,  // 22: feature.v1.FeatureService.Sum:input_type -> feature.v1.SumRequest
+	4,  // 23: feature.v1.FeatureService.Fail:input_type -> feature.v1.FailRequest
+	5,  // 24: feature.v1.FeatureService.Metadata:input_type -> feature.v1.MetadataRequest
+	7,  // 25: feature.v1.FeatureService.Slow:input_type -> feature.v1.SlowRequest
+	9,  // 26: feature.v1.FeatureService.Tree:input_type -> feature.v1.TreeNode
+	11, // 27: feature.v1.FeatureService.WellKnownTypes:input_type -> feature.v1.WellKnownMessage
+	1,  // 28: feature.v1.FeatureService.Ticker:output_type -> feature.v1.Tick
+	3,  // 29: feature.v1.FeatureService.Sum:output_type -> feature.v1.SumSummary
+	22, // 30: feature.v1.FeatureService.Fail:output_type -> google.protobuf.Empty
+	6,  // 31: feature.v1.FeatureService.Metadata:output_type -> feature.v1.MetadataReply
+	8,  // 32: feature.v1.FeatureService.Slow:output_type -> feature.v1.SlowReply
+	10, // 33: feature.v1.FeatureService.Tree:output_type -> feature.v1.TreeSummary
+	11, // 34: feature.v1.FeatureService.WellKnownTypes:output_type -> feature.v1.WellKnownMessage
+	28, // [28:35] is the sub-list for method output_type
+	21, // [21:28] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_cmd_testserver_feature_feature_proto_init() }
+func file_cmd_testserver_feature_feature_proto_init() {
+	if File_cmd_testserver_feature_feature_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cmd_testserver_feature_feature_proto_rawDesc), len(file_cmd_testserver_feature_feature_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cmd_testserver_feature_feature_proto_goTypes,
+		DependencyIndexes: file_cmd_testserver_feature_feature_proto_depIdxs,
+		MessageInfos:      file_cmd_testserver_feature_feature_proto_msgTypes,
+	}.Build()
+	File_cmd_testserver_feature_feature_proto = out.File
+	file_cmd_testserver_feature_feature_proto_goTypes = nil
+	file_cmd_testserver_feature_feature_proto_depIdxs = nil
+}
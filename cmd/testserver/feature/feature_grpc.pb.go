@@ -0,0 +1,361 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: cmd/testserver/feature/feature.proto
+
+package featurev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FeatureService_Ticker_FullMethodName         = "/feature.v1.FeatureService/Ticker"
+	FeatureService_Sum_FullMethodName            = "/feature.v1.FeatureService/Sum"
+	FeatureService_Fail_FullMethodName           = "/feature.v1.FeatureService/Fail"
+	FeatureService_Metadata_FullMethodName       = "/feature.v1.FeatureService/Metadata"
+	FeatureService_Slow_FullMethodName           = "/feature.v1.FeatureService/Slow"
+	FeatureService_Tree_FullMethodName           = "/feature.v1.FeatureService/Tree"
+	FeatureService_WellKnownTypes_FullMethodName = "/feature.v1.FeatureService/WellKnownTypes"
+)
+
+// FeatureServiceClient is the client API for FeatureService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FeatureServiceClient interface {
+	// Ticker sends count ticks, one every interval.
+	Ticker(ctx context.Context, in *TickerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Tick], error)
+	// Sum aggregates every value sent and replies with a summary once the client closes the stream.
+	Sum(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SumRequest, SumSummary], error)
+	// Fail always fails with the requested code and rich error details.
+	Fail(ctx context.Context, in *FailRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Metadata sets the requested headers and trailers and echoes the request metadata.
+	Metadata(ctx context.Context, in *MetadataRequest, opts ...grpc.CallOption) (*MetadataReply, error)
+	// Slow waits before replying, or fails once the deadline passes.
+	Slow(ctx context.Context, in *SlowRequest, opts ...grpc.CallOption) (*SlowReply, error)
+	// Tree counts the nodes of a recursive message.
+	Tree(ctx context.Context, in *TreeNode, opts ...grpc.CallOption) (*TreeSummary, error)
+	// WellKnownTypes echoes a message made of well-known types.
+	WellKnownTypes(ctx context.Context, in *WellKnownMessage, opts ...grpc.CallOption) (*WellKnownMessage, error)
+}
+
+type featureServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFeatureServiceClient(cc grpc.ClientConnInterface) FeatureServiceClient {
+	return &featureServiceClient{cc}
+}
+
+func (c *featureServiceClient) Ticker(ctx context.Context, in *TickerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Tick], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FeatureService_ServiceDesc.Streams[0], FeatureService_Ticker_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TickerRequest, Tick]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FeatureService_TickerClient = grpc.ServerStreamingClient[Tick]
+
+func (c *featureServiceClient) Sum(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SumRequest, SumSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FeatureService_ServiceDesc.Streams[1], FeatureService_Sum_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SumRequest, SumSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FeatureService_SumClient = grpc.ClientStreamingClient[SumRequest, SumSummary]
+
+func (c *featureServiceClient) Fail(ctx context.Context, in *FailRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FeatureService_Fail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureServiceClient) Metadata(ctx context.Context, in *MetadataRequest, opts ...grpc.CallOption) (*MetadataReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MetadataReply)
+	err := c.cc.Invoke(ctx, FeatureService_Metadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureServiceClient) Slow(ctx context.Context, in *SlowRequest, opts ...grpc.CallOption) (*SlowReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SlowReply)
+	err := c.cc.Invoke(ctx, FeatureService_Slow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureServiceClient) Tree(ctx context.Context, in *TreeNode, opts ...grpc.CallOption) (*TreeSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TreeSummary)
+	err := c.cc.Invoke(ctx, FeatureService_Tree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureServiceClient) WellKnownTypes(ctx context.Context, in *WellKnownMessage, opts ...grpc.CallOption) (*WellKnownMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WellKnownMessage)
+	err := c.cc.Invoke(ctx, FeatureService_WellKnownTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FeatureServiceServer is the server API for FeatureService service.
+// All implementations must embed UnimplementedFeatureServiceServer
+// for forward compatibility.
+type FeatureServiceServer interface {
+	// Ticker sends count ticks, one every interval.
+	Ticker(*TickerRequest, grpc.ServerStreamingServer[Tick]) error
+	// Sum aggregates every value sent and replies with a summary once the client closes the stream.
+	Sum(grpc.ClientStreamingServer[SumRequest, SumSummary]) error
+	// Fail always fails with the requested code and rich error details.
+	Fail(context.Context, *FailRequest) (*emptypb.Empty, error)
+	// Metadata sets the requested headers and trailers and echoes the request metadata.
+	Metadata(context.Context, *MetadataRequest) (*MetadataReply, error)
+	// Slow waits before replying, or fails once the deadline passes.
+	Slow(context.Context, *SlowRequest) (*SlowReply, error)
+	// Tree counts the nodes of a recursive message.
+	Tree(context.Context, *TreeNode) (*TreeSummary, error)
+	// WellKnownTypes echoes a message made of well-known types.
+	WellKnownTypes(context.Context, *WellKnownMessage) (*WellKnownMessage, error)
+	mustEmbedUnimplementedFeatureServiceServer()
+}
+
+// UnimplementedFeatureServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFeatureServiceServer struct{}
+
+func (UnimplementedFeatureServiceServer) Ticker(*TickerRequest, grpc.ServerStreamingServer[Tick]) error {
+	return status.Errorf(codes.Unimplemented, "method Ticker not implemented")
+}
+func (UnimplementedFeatureServiceServer) Sum(grpc.ClientStreamingServer[SumRequest, SumSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method Sum not implemented")
+}
+func (UnimplementedFeatureServiceServer) Fail(context.Context, *FailRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fail not implemented")
+}
+func (UnimplementedFeatureServiceServer) Metadata(context.Context, *MetadataRequest) (*MetadataReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Metadata not implemented")
+}
+func (UnimplementedFeatureServiceServer) Slow(context.Context, *SlowRequest) (*SlowReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Slow not implemented")
+}
+func (UnimplementedFeatureServiceServer) Tree(context.Context, *TreeNode) (*TreeSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Tree not implemented")
+}
+func (UnimplementedFeatureServiceServer) WellKnownTypes(context.Context, *WellKnownMessage) (*WellKnownMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WellKnownTypes not implemented")
+}
+func (UnimplementedFeatureServiceServer) mustEmbedUnimplementedFeatureServiceServer() {}
+func (UnimplementedFeatureServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeFeatureServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FeatureServiceServer will
+// result in compilation errors.
+type UnsafeFeatureServiceServer interface {
+	mustEmbedUnimplementedFeatureServiceServer()
+}
+
+func RegisterFeatureServiceServer(s grpc.ServiceRegistrar, srv FeatureServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFeatureServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FeatureService_ServiceDesc, srv)
+}
+
+func _FeatureService_Ticker_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TickerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeatureServiceServer).Ticker(m, &grpc.GenericServerStream[TickerRequest, Tick]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FeatureService_TickerServer = grpc.ServerStreamingServer[Tick]
+
+func _FeatureService_Sum_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FeatureServiceServer).Sum(&grpc.GenericServerStream[SumRequest, SumSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FeatureService_SumServer = grpc.ClientStreamingServer[SumRequest, SumSummary]
+
+func _FeatureService_Fail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureServiceServer).Fail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureService_Fail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureServiceServer).Fail(ctx, req.(*FailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureService_Metadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureServiceServer).Metadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureService_Metadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureServiceServer).Metadata(ctx, req.(*MetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureService_Slow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureServiceServer).Slow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureService_Slow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureServiceServer).Slow(ctx, req.(*SlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureService_Tree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TreeNode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureServiceServer).Tree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureService_Tree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureServiceServer).Tree(ctx, req.(*TreeNode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureService_WellKnownTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WellKnownMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureServiceServer).WellKnownTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureService_WellKnownTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureServiceServer).WellKnownTypes(ctx, req.(*WellKnownMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FeatureService_ServiceDesc is the grpc.ServiceDesc for FeatureService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FeatureService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "feature.v1.FeatureService",
+	HandlerType: (*FeatureServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Fail",
+			Handler:    _FeatureService_Fail_Handler,
+		},
+		{
+			MethodName: "Metadata",
+			Handler:    _FeatureService_Metadata_Handler,
+		},
+		{
+			MethodName: "Slow",
+			Handler:    _FeatureService_Slow_Handler,
+		},
+		{
+			MethodName: "Tree",
+			Handler:    _FeatureService_Tree_Handler,
+		},
+		{
+			MethodName: "WellKnownTypes",
+			Handler:    _FeatureService_WellKnownTypes_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Ticker",
+			Handler:       _FeatureService_Ticker_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Sum",
+			Handler:       _FeatureService_Sum_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "cmd/testserver/feature/feature.proto",
+}
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.6
+// 	protoc-gen-go v1.36.10
 // 	protoc        (unknown)
 // source: cmd/testserver/hello/hello.proto
 
@@ -109,6 +109,112 @@ func (x *HelloReply) GetMessage() string {
 	return ""
 }
 
+type FirehoseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// number of replies to send, 0 streams until the call is cancelled
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// size in bytes of the payload padding each reply
+	PayloadSize   uint32 `protobuf:"varint,2,opt,name=payload_size,json=payloadSize,proto3" json:"payload_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirehoseRequest) Reset() {
+	*x = FirehoseRequest{}
+	mi := &file_cmd_testserver_hello_hello_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirehoseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirehoseRequest) ProtoMessage() {}
+
+func (x *FirehoseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_hello_hello_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirehoseRequest.ProtoReflect.Descriptor instead.
+func (*FirehoseRequest) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_hello_hello_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FirehoseRequest) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *FirehoseRequest) GetPayloadSize() uint32 {
+	if x != nil {
+		return x.PayloadSize
+	}
+	return 0
+}
+
+type FirehoseReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Payload       string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirehoseReply) Reset() {
+	*x = FirehoseReply{}
+	mi := &file_cmd_testserver_hello_hello_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirehoseReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirehoseReply) ProtoMessage() {}
+
+func (x *FirehoseReply) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_testserver_hello_hello_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirehoseReply.ProtoReflect.Descriptor instead.
+func (*FirehoseReply) Descriptor() ([]byte, []int) {
+	return file_cmd_testserver_hello_hello_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FirehoseReply) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *FirehoseReply) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
 var File_cmd_testserver_hello_hello_proto protoreflect.FileDescriptor
 
 const file_cmd_testserver_hello_hello_proto_rawDesc = "" +
@@ -118,9 +224,16 @@ const file_cmd_testserver_hello_hello_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tR\x04name\"&\n" +
 	"\n" +
 	"HelloReply\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2O\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"J\n" +
+	"\x0fFirehoseRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\x12!\n" +
+	"\fpayload_size\x18\x02 \x01(\rR\vpayloadSize\";\n" +
+	"\rFirehoseReply\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\tR\apayload2\x91\x01\n" +
 	"\fHelloService\x12?\n" +
-	"\vHelloStream\x12\x16.hello.v1.HelloRequest\x1a\x14.hello.v1.HelloReply(\x010\x01B\xa2\x01\n" +
+	"\vHelloStream\x12\x16.hello.v1.HelloRequest\x1a\x14.hello.v1.HelloReply(\x010\x01\x12@\n" +
+	"\bFirehose\x12\x19.hello.v1.FirehoseRequest\x1a\x17.hello.v1.FirehoseReply0\x01B\xa2\x01\n" +
 	"\fcom.hello.v1B\n" +
 	"HelloProtoP\x01ZEgithub.com/prnvbn/grpcexp/cmd/testserver/cmd/testserver/hello;hellov1\xa2\x02\x03HXX\xaa\x02\bHello.V1\xca\x02\bHello\\V1\xe2\x02\x14Hello\\V1\\GPBMetadata\xea\x02\tHello::V1b\x06proto3"
 
@@ -136,16 +249,20 @@ func file_cmd_testserver_hello_hello_proto_rawDescGZIP() []byte {
 	return file_cmd_testserver_hello_hello_proto_rawDescData
 }
 
-var file_cmd_testserver_hello_hello_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_cmd_testserver_hello_hello_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_cmd_testserver_hello_hello_proto_goTypes = []any{
-	(*HelloRequest)(nil), // 0: hello.v1.HelloRequest
-	(*HelloReply)(nil),   // 1: hello.v1.HelloReply
+	(*HelloRequest)(nil),    // 0: hello.v1.HelloRequest
+	(*HelloReply)(nil),      // 1: hello.v1.HelloReply
+	(*FirehoseRequest)(nil), // 2: hello.v1.FirehoseRequest
+	(*FirehoseReply)(nil),   // 3: hello.v1.FirehoseReply
 }
 var file_cmd_testserver_hello_hello_proto_depIdxs = []int32{
 	0, // 0: hello.v1.HelloService.HelloStream:input_type -> hello.v1.HelloRequest
-	1, // 1: hello.v1.HelloService.HelloStream:output_type -> hello.v1.HelloReply
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
+	2, // 1: hello.v1.HelloService.Firehose:input_type -> hello.v1.FirehoseRequest
+	1, // 2: hello.v1.HelloService.HelloStream:output_type -> hello.v1.HelloReply
+	3, // 3: hello.v1.HelloService.Firehose:output_type -> hello.v1.FirehoseReply
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -162,7 +279,7 @@ func file_cmd_testserver_hello_hello_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cmd_testserver_hello_hello_proto_rawDesc), len(file_cmd_testserver_hello_hello_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
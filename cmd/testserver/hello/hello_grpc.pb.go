@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	HelloService_HelloStream_FullMethodName = "/hello.v1.HelloService/HelloStream"
+	HelloService_Firehose_FullMethodName    = "/hello.v1.HelloService/Firehose"
 )
 
 // HelloServiceClient is the client API for HelloService service.
@@ -27,6 +28,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type HelloServiceClient interface {
 	HelloStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HelloRequest, HelloReply], error)
+	// Firehose sends replies as fast as the client reads them.
+	Firehose(ctx context.Context, in *FirehoseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FirehoseReply], error)
 }
 
 type helloServiceClient struct {
@@ -50,11 +53,32 @@ func (c *helloServiceClient) HelloStream(ctx context.Context, opts ...grpc.CallO
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type HelloService_HelloStreamClient = grpc.BidiStreamingClient[HelloRequest, HelloReply]
 
+func (c *helloServiceClient) Firehose(ctx context.Context, in *FirehoseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FirehoseReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HelloService_ServiceDesc.Streams[1], HelloService_Firehose_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FirehoseRequest, FirehoseReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HelloService_FirehoseClient = grpc.ServerStreamingClient[FirehoseReply]
+
 // HelloServiceServer is the server API for HelloService service.
 // All implementations must embed UnimplementedHelloServiceServer
 // for forward compatibility.
 type HelloServiceServer interface {
 	HelloStream(grpc.BidiStreamingServer[HelloRequest, HelloReply]) error
+	// Firehose sends replies as fast as the client reads them.
+	Firehose(*FirehoseRequest, grpc.ServerStreamingServer[FirehoseReply]) error
 	mustEmbedUnimplementedHelloServiceServer()
 }
 
@@ -68,6 +92,9 @@ type UnimplementedHelloServiceServer struct{}
 func (UnimplementedHelloServiceServer) HelloStream(grpc.BidiStreamingServer[HelloRequest, HelloReply]) error {
 	return status.Errorf(codes.Unimplemented, "method HelloStream not implemented")
 }
+func (UnimplementedHelloServiceServer) Firehose(*FirehoseRequest, grpc.ServerStreamingServer[FirehoseReply]) error {
+	return status.Errorf(codes.Unimplemented, "method Firehose not implemented")
+}
 func (UnimplementedHelloServiceServer) mustEmbedUnimplementedHelloServiceServer() {}
 func (UnimplementedHelloServiceServer) testEmbeddedByValue()                      {}
 
@@ -96,6 +123,17 @@ func _HelloService_HelloStream_Handler(srv interface{}, stream grpc.ServerStream
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type HelloService_HelloStreamServer = grpc.BidiStreamingServer[HelloRequest, HelloReply]
 
+func _HelloService_Firehose_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FirehoseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HelloServiceServer).Firehose(m, &grpc.GenericServerStream[FirehoseRequest, FirehoseReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HelloService_FirehoseServer = grpc.ServerStreamingServer[FirehoseReply]
+
 // HelloService_ServiceDesc is the grpc.ServiceDesc for HelloService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -110,6 +148,11 @@ var HelloService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Firehose",
+			Handler:       _HelloService_Firehose_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "cmd/testserver/hello/hello.proto",
 }
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	featurev1 "github.com/prnvbn/grpcexp/cmd/testserver/feature"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultTickInterval = 100 * time.Millisecond
+
+type featureServer struct {
+	featurev1.UnimplementedFeatureServiceServer
+
+	log *log.Logger
+}
+
+func (s *featureServer) Ticker(in *featurev1.TickerRequest, stream featurev1.FeatureService_TickerServer) error {
+	interval := in.GetInterval().AsDuration()
+	if interval <= 0 {
+		interval = defaultTickInterval
+	}
+	s.log.Printf("Received ticker: count=%d interval=%s", in.GetCount(), interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for seq := uint32(1); seq <= in.GetCount(); seq++ {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case now := <-ticker.C:
+			if err := stream.Send(&featurev1.Tick{Seq: seq, Time: timestamppb.New(now)}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *featureServer) Sum(stream featurev1.FeatureService_SumServer) error {
+	summary := &featurev1.SumSummary{}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			if summary.Count > 0 {
+				summary.Mean = float64(summary.Sum) / float64(summary.Count)
+			}
+			return stream.SendAndClose(summary)
+		}
+		if err != nil {
+			return err
+		}
+
+		s.log.Printf("Received sum: %d %q", req.GetValue(), req.GetLabel())
+		if summary.Count == 0 || req.GetValue() < summary.Min {
+			summary.Min = req.GetValue()
+		}
+		if summary.Count == 0 || req.GetValue() > summary.Max {
+			summary.Max = req.GetValue()
+		}
+		summary.Count++
+		summary.Sum += req.GetValue()
+		if req.GetLabel() != "" {
+			summary.Labels = append(summary.Labels, req.GetLabel())
+		}
+	}
+}
+
+func (s *featureServer) Fail(_ context.Context, in *featurev1.FailRequest) (*emptypb.Empty, error) {
+	code := codes.Code(in.GetCode())
+	if code == codes.OK {
+		code = codes.InvalidArgument
+	}
+	message := in.GetMessage()
+	if message == "" {
+		message = "request failed on purpose"
+	}
+	s.log.Printf("Received fail: %s %q", code, message)
+
+	st, err := status.New(code, message).WithDetails(
+		&errdetails.ErrorInfo{
+			Reason:   "DEMO_FAILURE",
+			Domain:   "testserver.grpcexp",
+			Metadata: map[string]string{"method": "Fail"},
+		},
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "message", Description: "this method never succeeds"},
+			},
+		},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)},
+		&errdetails.DebugInfo{Detail: "returned by cmd/testserver"},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return nil, st.Err()
+}
+
+func (s *featureServer) Metadata(ctx context.Context, in *featurev1.MetadataRequest) (*featurev1.MetadataReply, error) {
+	if err := grpc.SetHeader(ctx, metadata.New(in.GetHeaders())); err != nil {
+		return nil, err
+	}
+	if err := grpc.SetTrailer(ctx, metadata.New(in.GetTrailers())); err != nil {
+		return nil, err
+	}
+
+	reply := &featurev1.MetadataReply{Received: make(map[string]string)}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		reply.Received[key] = strings.Join(values, ", ")
+	}
+	s.log.Printf("Received metadata: %v", sortedKeys(reply.Received))
+	return reply, nil
+}
+
+func (s *featureServer) Slow(ctx context.Context, in *featurev1.SlowRequest) (*featurev1.SlowReply, error) {
+	delay := in.GetDelay().AsDuration()
+	s.log.Printf("Received slow: %s", delay)
+
+	start := time.Now()
+	select {
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case <-time.After(delay):
+	}
+	return &featurev1.SlowReply{Waited: durationpb.New(time.Since(start))}, nil
+}
+
+func (s *featureServer) Tree(_ context.Context, in *featurev1.TreeNode) (*featurev1.TreeSummary, error) {
+	summary := &featurev1.TreeSummary{}
+	var walk func(node *featurev1.TreeNode, depth uint32)
+	walk = func(node *featurev1.TreeNode, depth uint32) {
+		summary.Nodes++
+		summary.Depth = max(summary.Depth, depth)
+		for _, child := range node.GetChildren() {
+			walk(child, depth+1)
+		}
+	}
+	walk(in, 1)
+	s.log.Printf("Received tree: %d nodes", summary.Nodes)
+	return summary, nil
+}
+
+func (s *featureServer) WellKnownTypes(_ context.Context, in *featurev1.WellKnownMessage) (*featurev1.WellKnownMessage, error) {
+	s.log.Printf("Received well-known types: %v", in)
+	return in, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
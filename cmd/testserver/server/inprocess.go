@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Dialer dials a server started by Listen.
+type Dialer func(ctx context.Context, addr string) (net.Conn, error)
+
+// Listen serves s in memory until the test ends and returns a dialer for it.
+func Listen(t testing.TB, s *grpc.Server) Dialer {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	return func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}
+}
+
+// Serve serves s in memory until the test ends and returns a connection to
+// it.
+func Serve(t testing.TB, s *grpc.Server) *grpc.ClientConn {
+	t.Helper()
+	return connect(t, Listen(t, s))
+}
+
+// Start starts the test server in memory, without logging calls, and returns
+// a dialer for it.
+func Start(t testing.TB, opts ...grpc.ServerOption) Dialer {
+	t.Helper()
+
+	s := grpc.NewServer(opts...)
+	Register(s, Quiet())
+	return Listen(t, s)
+}
+
+// Dial starts the test server in memory, without logging calls, and returns a
+// connection to it.
+func Dial(t testing.TB, opts ...grpc.ServerOption) *grpc.ClientConn {
+	t.Helper()
+	return connect(t, Start(t, opts...))
+}
+
+func connect(t testing.TB, dialer Dialer) *grpc.ClientConn {
+	t.Helper()
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = cc.Close() })
+	return cc
+}
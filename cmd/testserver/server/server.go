@@ -0,0 +1,118 @@
+// Package server implements the services of the test server so they can also
+// be started in-process by tests.
+package server
+
+import (
+	"context"
+	"io"
+	"log"
+	"strings"
+
+	echov1 "github.com/prnvbn/grpcexp/cmd/testserver/echo"
+	featurev1 "github.com/prnvbn/grpcexp/cmd/testserver/feature"
+	hellov1 "github.com/prnvbn/grpcexp/cmd/testserver/hello"
+	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// Option configures the services Register registers.
+type Option func(*options)
+
+type options struct {
+	log *log.Logger
+}
+
+// WithLogger logs the calls the services receive to l instead of the standard
+// logger.
+func WithLogger(l *log.Logger) Option {
+	return func(o *options) { o.log = l }
+}
+
+// Quiet discards the log of calls, which tests have no use for.
+func Quiet() Option {
+	return WithLogger(log.New(io.Discard, "", 0))
+}
+
+// Register registers every test service and reflection on s.
+func Register(s *grpc.Server, opts ...Option) {
+	o := options{log: log.Default()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	helloworldpb.RegisterGreeterServer(s, &server{log: o.log})
+	echov1.RegisterEchoServiceServer(s, &server{log: o.log})
+	hellov1.RegisterHelloServiceServer(s, &server{log: o.log})
+	featurev1.RegisterFeatureServiceServer(s, &featureServer{log: o.log})
+
+	reflection.Register(s)
+}
+
+type server struct {
+	helloworldpb.UnimplementedGreeterServer
+	echov1.UnimplementedEchoServiceServer
+	hellov1.UnimplementedHelloServiceServer
+
+	log *log.Logger
+}
+
+func (s *server) SayHello(_ context.Context, in *helloworldpb.HelloRequest) (*helloworldpb.HelloReply, error) {
+	s.log.Printf("Received: %v", in.GetName())
+	return &helloworldpb.HelloReply{Message: "Hello " + in.GetName()}, nil
+}
+
+func (s *server) Echo(_ context.Context, in *echov1.Message) (*echov1.Message, error) {
+	s.log.Printf("Received: %v", in.GetMessage())
+	return in, nil
+}
+
+func (s *server) EchoStream(stream echov1.EchoService_EchoStreamServer) error {
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		s.log.Printf("Received stream: %v", msg.GetMessage())
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) HelloStream(stream hellov1.HelloService_HelloStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := req.GetName()
+		if name == "" {
+			name = "there"
+		}
+		s.log.Printf("Received hello stream: %v", name)
+		if err := stream.Send(&hellov1.HelloReply{Message: "Hello " + name}); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) Firehose(in *hellov1.FirehoseRequest, stream hellov1.HelloService_FirehoseServer) error {
+	s.log.Printf("Received firehose: count=%d payload_size=%d", in.GetCount(), in.GetPayloadSize())
+	payload := strings.Repeat("x", int(in.GetPayloadSize()))
+	for seq := uint64(1); in.GetCount() == 0 || seq <= in.GetCount(); seq++ {
+		if err := stream.Send(&hellov1.FirehoseReply{Seq: seq, Payload: payload}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Package bench sends the same call over and over and summarises its latency,
+// throughput and status codes.
+package bench
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultConcurrency = 10
+	DefaultCount       = 200
+)
+
+// Call sends one request. The error carries the gRPC status of failed calls.
+type Call func(ctx context.Context) error
+
+type Options struct {
+	// Concurrency is the number of calls in flight at once.
+	Concurrency int
+	// Count stops the run after this many calls when non-zero.
+	Count int
+	// Duration stops the run after this long when non-zero. Without a Count or
+	// Duration the run stops after DefaultCount calls.
+	Duration time.Duration
+	// Rate limits the run to this many calls per second when non-zero.
+	Rate float64
+}
+
+// Runner runs a benchmark and reports its progress while it runs.
+type Runner struct {
+	call      Call
+	opts      Options
+	completed atomic.Int64
+}
+
+func NewRunner(call Call, opts Options) *Runner {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.Count <= 0 && opts.Duration <= 0 {
+		opts.Count = DefaultCount
+	}
+	return &Runner{call: call, opts: opts}
+}
+
+func (r *Runner) Options() Options {
+	return r.opts
+}
+
+// Completed returns the number of calls that have finished so far.
+func (r *Runner) Completed() int {
+	return int(r.completed.Load())
+}
+
+type sample struct {
+	latency time.Duration
+	err     error
+}
+
+// Run sends calls until the count or duration is reached or ctx is done, and
+// reports on the calls that finished.
+func (r *Runner) Run(ctx context.Context) Report {
+	stop := make(chan struct{})
+	if r.opts.Duration > 0 {
+		timer := time.AfterFunc(r.opts.Duration, func() { close(stop) })
+		defer timer.Stop()
+	}
+
+	var tokens <-chan time.Time
+	if r.opts.Rate > 0 {
+		ticker := time.NewTicker(max(time.Duration(float64(time.Second)/r.opts.Rate), 1))
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+
+	var issued atomic.Int64
+	// next reports whether another call may be sent, waiting for the rate limit
+	next := func() bool {
+		if r.opts.Count > 0 && issued.Add(1) > int64(r.opts.Count) {
+			return false
+		}
+		if tokens == nil {
+			select {
+			case <-stop:
+				return false
+			case <-ctx.Done():
+				return false
+			default:
+				return true
+			}
+		}
+		select {
+		case <-tokens:
+			return true
+		case <-stop:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	samples := make([][]sample, r.opts.Concurrency)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for worker := range samples {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for next() {
+				callStart := time.Now()
+				err := r.call(ctx)
+				samples[worker] = append(samples[worker], sample{latency: time.Since(callStart), err: err})
+				r.completed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var all []sample
+	for _, s := range samples {
+		all = append(all, s...)
+	}
+	return newReport(all, time.Since(start))
+}
@@ -0,0 +1,115 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRunCount(t *testing.T) {
+	var calls atomic.Int64
+	call := func(context.Context) error {
+		if calls.Add(1)%5 == 0 {
+			return status.Error(codes.Unavailable, "try again")
+		}
+		return nil
+	}
+
+	runner := NewRunner(call, Options{Concurrency: 4, Count: 50})
+	report := runner.Run(context.Background())
+
+	if report.Count != 50 || runner.Completed() != 50 || calls.Load() != 50 {
+		t.Fatalf("count = %d, completed = %d, calls = %d, want 50", report.Count, runner.Completed(), calls.Load())
+	}
+	if report.Statuses["OK"] != 40 || report.Statuses["Unavailable"] != 10 {
+		t.Fatalf("statuses = %v, want 40 OK and 10 Unavailable", report.Statuses)
+	}
+	if report.Errors["try again"] != 10 {
+		t.Fatalf("errors = %v, want 10 try again", report.Errors)
+	}
+
+	histogramCount := 0
+	for _, b := range report.Histogram {
+		histogramCount += b.Count
+	}
+	if histogramCount != 50 {
+		t.Fatalf("histogram holds %d calls, want 50", histogramCount)
+	}
+}
+
+func TestRunDurationAndRate(t *testing.T) {
+	runner := NewRunner(func(context.Context) error { return nil }, Options{
+		Concurrency: 2,
+		Duration:    200 * time.Millisecond,
+		Rate:        50,
+	})
+	report := runner.Run(context.Background())
+
+	// 50 calls/s for 200ms is 10 calls, allow for timer jitter
+	if report.Count < 5 || report.Count > 12 {
+		t.Fatalf("count = %d, want about 10", report.Count)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		n    int
+		p    float64
+		want int // index into the sorted latencies
+	}{
+		{n: 100, p: 50, want: 49},
+		{n: 100, p: 90, want: 89},
+		{n: 100, p: 99, want: 98},
+		{n: 1009, p: 50, want: 504},
+		{n: 1009, p: 90, want: 908},
+		{n: 1009, p: 99, want: 998},
+		{n: 3, p: 50, want: 1},
+		{n: 1, p: 99, want: 0},
+		{n: 10, p: 0, want: 0},
+		{n: 10, p: 100, want: 9},
+	}
+
+	for _, tt := range tests {
+		latencies := make([]time.Duration, tt.n)
+		for i := range latencies {
+			latencies[i] = time.Duration(i) * time.Millisecond
+		}
+		if got, want := percentile(latencies, tt.p), latencies[tt.want]; got != want {
+			t.Errorf("p%v of %d = %s, want %s", tt.p, tt.n, got, want)
+		}
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	report := newReport([]sample{
+		{latency: 2 * time.Millisecond},
+		{latency: 4 * time.Millisecond, err: status.Error(codes.Internal, "boom")},
+	}, time.Second)
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var got struct {
+		Count     int                `json:"count"`
+		Rate      float64            `json:"rate"`
+		LatencyMs map[string]float64 `json:"latency_ms"`
+		Statuses  map[string]int     `json:"statuses"`
+		Errors    map[string]int     `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if got.Count != 2 || got.Rate != 2 || got.LatencyMs["max"] != 4 || got.LatencyMs["mean"] != 3 {
+		t.Fatalf("report JSON = %s", data)
+	}
+	if got.Statuses["OK"] != 1 || got.Statuses["Internal"] != 1 || got.Errors["boom"] != 1 {
+		t.Fatalf("report JSON = %s", data)
+	}
+}
@@ -0,0 +1,239 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+const (
+	histogramBuckets = 10
+	histogramWidth   = 40
+)
+
+// Report summarises a benchmark run.
+type Report struct {
+	Count int
+	// Total is the wall time of the run.
+	Total time.Duration
+	// Rate is the number of calls finished per second.
+	Rate      float64
+	Latency   Latency
+	Histogram []Bucket
+	// Statuses counts calls by gRPC status code name.
+	Statuses map[string]int
+	// Errors counts failed calls by error message.
+	Errors map[string]int
+}
+
+type Latency struct {
+	Min, Mean, P50, P90, P99, Max time.Duration
+}
+
+// Bucket counts the calls that took at most Upper and longer than the
+// previous bucket's Upper.
+type Bucket struct {
+	Upper time.Duration
+	Count int
+}
+
+func newReport(samples []sample, total time.Duration) Report {
+	report := Report{
+		Count:    len(samples),
+		Total:    total,
+		Statuses: make(map[string]int),
+		Errors:   make(map[string]int),
+	}
+	if total > 0 {
+		report.Rate = float64(len(samples)) / total.Seconds()
+	}
+	if len(samples) == 0 {
+		return report
+	}
+
+	latencies := make([]time.Duration, len(samples))
+	var sum time.Duration
+	for i, s := range samples {
+		latencies[i] = s.latency
+		sum += s.latency
+
+		st := status.Convert(s.err)
+		report.Statuses[st.Code().String()]++
+		if s.err != nil {
+			report.Errors[st.Message()]++
+		}
+	}
+	slices.Sort(latencies)
+
+	report.Latency = Latency{
+		Min:  latencies[0],
+		Mean: sum / time.Duration(len(latencies)),
+		P50:  percentile(latencies, 50),
+		P90:  percentile(latencies, 90),
+		P99:  percentile(latencies, 99),
+		Max:  latencies[len(latencies)-1],
+	}
+	report.Histogram = histogram(latencies)
+	return report
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted))/100)) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// histogram splits sorted latencies into equal-width buckets between the
+// fastest and the slowest call.
+func histogram(sorted []time.Duration) []Bucket {
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	width := (hi - lo) / histogramBuckets
+	if width == 0 {
+		return []Bucket{{Upper: hi, Count: len(sorted)}}
+	}
+
+	buckets := make([]Bucket, histogramBuckets)
+	for i := range buckets {
+		buckets[i].Upper = lo + width*time.Duration(i+1)
+	}
+	buckets[len(buckets)-1].Upper = hi
+
+	i := 0
+	for _, latency := range sorted {
+		for latency > buckets[i].Upper {
+			i++
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
+
+// String renders the report for a terminal.
+func (r Report) String() string {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "Summary:\n")
+	fmt.Fprintf(&out, "  count:    %d\n", r.Count)
+	fmt.Fprintf(&out, "  total:    %s\n", r.Total.Round(time.Millisecond))
+	fmt.Fprintf(&out, "  rate:     %.1f calls/s\n", r.Rate)
+	if r.Count == 0 {
+		return out.String()
+	}
+
+	fmt.Fprintf(&out, "\nLatency:\n")
+	for _, l := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"min", r.Latency.Min},
+		{"mean", r.Latency.Mean},
+		{"p50", r.Latency.P50},
+		{"p90", r.Latency.P90},
+		{"p99", r.Latency.P99},
+		{"max", r.Latency.Max},
+	} {
+		fmt.Fprintf(&out, "  %-8s  %s\n", l.name+":", formatLatency(l.value))
+	}
+
+	fmt.Fprintf(&out, "\nHistogram:\n")
+	most := 0
+	for _, b := range r.Histogram {
+		most = max(most, b.Count)
+	}
+	for _, b := range r.Histogram {
+		bar := strings.Repeat("■", b.Count*histogramWidth/most)
+		line := fmt.Sprintf("  %10s %-8s %s", formatLatency(b.Upper), fmt.Sprintf("[%d]", b.Count), bar)
+		out.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	fmt.Fprintf(&out, "\nStatus codes:\n")
+	for _, code := range sortedKeys(r.Statuses) {
+		fmt.Fprintf(&out, "  %s: %d\n", code, r.Statuses[code])
+	}
+
+	if len(r.Errors) > 0 {
+		fmt.Fprintf(&out, "\nErrors:\n")
+		for _, msg := range sortedKeys(r.Errors) {
+			fmt.Fprintf(&out, "  [%d] %s\n", r.Errors[msg], msg)
+		}
+	}
+	return out.String()
+}
+
+func formatLatency(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonReport is the JSON form of a Report with durations in milliseconds.
+type jsonReport struct {
+	Count     int            `json:"count"`
+	TotalMs   float64        `json:"total_ms"`
+	Rate      float64        `json:"rate"`
+	LatencyMs jsonLatency    `json:"latency_ms"`
+	Histogram []jsonBucket   `json:"histogram"`
+	Statuses  map[string]int `json:"statuses"`
+	Errors    map[string]int `json:"errors,omitempty"`
+}
+
+type jsonLatency struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+type jsonBucket struct {
+	UpperMs float64 `json:"upper_ms"`
+	Count   int     `json:"count"`
+}
+
+func (r Report) MarshalJSON() ([]byte, error) {
+	out := jsonReport{
+		Count:   r.Count,
+		TotalMs: ms(r.Total),
+		Rate:    r.Rate,
+		LatencyMs: jsonLatency{
+			Min:  ms(r.Latency.Min),
+			Mean: ms(r.Latency.Mean),
+			P50:  ms(r.Latency.P50),
+			P90:  ms(r.Latency.P90),
+			P99:  ms(r.Latency.P99),
+			Max:  ms(r.Latency.Max),
+		},
+		Histogram: make([]jsonBucket, len(r.Histogram)),
+		Statuses:  r.Statuses,
+		Errors:    r.Errors,
+	}
+	for i, b := range r.Histogram {
+		out.Histogram[i] = jsonBucket{UpperMs: ms(b.Upper), Count: b.Count}
+	}
+	return json.Marshal(out)
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
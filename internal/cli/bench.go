@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/prnvbn/grpcexp/internal/bench"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchData     string
+	benchDataFile string
+	benchOptions  bench.Options
+	benchJSON     bool
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench <method>",
+	Short: "load test a unary method",
+	Long:  `sends the same request to a unary method over and over and reports throughput, latency percentiles, a latency histogram and the status codes returned`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBench,
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	request, err := benchRequest()
+	if err != nil {
+		return err
+	}
+
+	grpcClient, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	call, err := grpcClient.PrepareUnary(ctx, args[0], request)
+	if err != nil {
+		return err
+	}
+	report := bench.NewRunner(bench.Call(call), benchOptions).Run(ctx)
+
+	out := cmd.OutOrStdout()
+	if benchJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	_, err = fmt.Fprint(out, report)
+	return err
+}
+
+// benchRequest reads the request from --data or --data-file, defaulting to an
+// empty message.
+func benchRequest() (map[string]any, error) {
+	data := []byte(benchData)
+	if benchDataFile != "" {
+		var err error
+		if benchDataFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(benchDataFile)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	request := map[string]any{}
+	if len(data) == 0 {
+		return request, nil
+	}
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+	return request, nil
+}
+
+func init() {
+	benchCmd.Flags().StringVarP(&benchData, "data", "d", "", "JSON request to send")
+	benchCmd.Flags().StringVarP(&benchDataFile, "data-file", "f", "", "file holding the JSON request (- reads stdin)")
+	benchCmd.Flags().IntVarP(&benchOptions.Concurrency, "concurrency", "c", bench.DefaultConcurrency, "number of calls in flight at once")
+	benchCmd.Flags().IntVarP(&benchOptions.Count, "count", "n", 0, fmt.Sprintf("number of calls to send (%d if neither --count nor --duration is set)", bench.DefaultCount))
+	benchCmd.Flags().DurationVar(&benchOptions.Duration, "duration", 0, "send calls for this long")
+	benchCmd.Flags().Float64Var(&benchOptions.Rate, "rate", 0, "maximum calls per second, 0 for no limit")
+	benchCmd.Flags().BoolVar(&benchJSON, "json", false, "print the report as JSON")
+	benchCmd.MarkFlagsMutuallyExclusive("data", "data-file")
+	rootCmd.AddCommand(benchCmd)
+}
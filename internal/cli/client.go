@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prnvbn/grpcexp/internal/export"
+	"github.com/prnvbn/grpcexp/internal/grpc"
+	"github.com/prnvbn/grpcexp/internal/placeholder"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	port        int
+	addr        string
+	protoset    string
+	protoFiles  []string
+	importPaths []string
+	useTLS      bool
+	unix        bool
+	offline     bool
+	noCache     bool
+	timeout     time.Duration
+
+	maxRecvMsgSize        int
+	maxSendMsgSize        int
+	keepaliveTime         time.Duration
+	keepaliveTimeout      time.Duration
+	authority             string
+	headers               []string
+	useGzip               bool
+	initialWindowSize     int32
+	initialConnWindowSize int32
+
+	token              string
+	tokenExec          string
+	oauth2TokenURL     string
+	oauth2ClientID     string
+	oauth2ClientSecret string
+	oauth2Scopes       []string
+	revealTokens       bool
+)
+
+// newClient connects to the server selected by the connection flags.
+func newClient() (*grpc.Client, error) {
+	config, err := clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return connect(config)
+}
+
+func connect(config grpc.Config) (*grpc.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return grpc.NewClient(ctx, config)
+}
+
+func transportCreds(useTLS bool) credentials.TransportCredentials {
+	if useTLS {
+		return credentials.NewTLS(&tls.Config{})
+	}
+	return insecure.NewCredentials()
+}
+
+func clientConfig() (grpc.Config, error) {
+	var target string
+	if addr != "" {
+		target = addr
+	} else {
+		target = fmt.Sprintf("localhost:%d", port)
+	}
+
+	network, target := grpc.ParseTarget(target)
+	if unix {
+		if addr == "" {
+			return grpc.Config{}, fmt.Errorf("--unix requires --addr to be a socket path")
+		}
+		network = grpc.NetworkUnix
+	}
+
+	if protoset != "" && len(protoFiles) > 0 {
+		return grpc.Config{}, fmt.Errorf("only one of --protoset and --proto may be set")
+	}
+
+	auth, err := tokenProvider()
+	if err != nil {
+		return grpc.Config{}, err
+	}
+
+	var cacheDir string
+	if !noCache {
+		// without a usable cache dir we simply reflect every time
+		cacheDir, _ = grpc.DefaultCacheDir()
+	}
+
+	return grpc.Config{
+		Target:       target,
+		Network:      network,
+		Creds:        transportCreds(useTLS),
+		UserAgent:    "grpcexp/" + strings.TrimSpace(version),
+		Protoset:     protoset,
+		ProtoFiles:   protoFiles,
+		ImportPaths:  importPaths,
+		Offline:      offline,
+		CacheDir:     cacheDir,
+		Auth:         auth,
+		Headers:      headers,
+		RevealTokens: revealTokens,
+
+		MaxRecvMsgSize:        maxRecvMsgSize,
+		MaxSendMsgSize:        maxSendMsgSize,
+		KeepaliveTime:         keepaliveTime,
+		KeepaliveTimeout:      keepaliveTimeout,
+		Authority:             authority,
+		Gzip:                  useGzip,
+		InitialWindowSize:     initialWindowSize,
+		InitialConnWindowSize: initialConnWindowSize,
+	}, nil
+}
+
+// tokenProvider builds the token provider selected by the auth flags or, if
+// none are set, by the profile's auth.
+func tokenProvider() (grpc.TokenProvider, error) {
+	var oauth2 *grpc.OAuth2ClientCredentials
+	if oauth2TokenURL != "" {
+		oauth2 = &grpc.OAuth2ClientCredentials{
+			TokenURL:     oauth2TokenURL,
+			ClientID:     oauth2ClientID,
+			ClientSecret: oauth2ClientSecret,
+			Scopes:       oauth2Scopes,
+		}
+	}
+
+	providers, err := tokenProviders(token, tokenExec, oauth2)
+	if err != nil {
+		return nil, err
+	}
+	switch len(providers) {
+	case 0:
+		return profileTokenProvider()
+	case 1:
+		return providers[0], nil
+	default:
+		return nil, fmt.Errorf("only one of --token, --token-exec and --oauth2-token-url may be set")
+	}
+}
+
+// profileTokenProvider builds the token provider of the selected profile's
+// auth, if it has one, after filling in its placeholders.
+func profileTokenProvider() (grpc.TokenProvider, error) {
+	p, err := selectedProfile()
+	if err != nil || p.Auth == nil {
+		return nil, err
+	}
+	vars, err := templateVars()
+	if err != nil {
+		return nil, err
+	}
+	expand := func(s string) string {
+		return placeholder.ExpandString(s, placeholder.Context{Vars: vars})
+	}
+
+	auth := p.Auth
+	var oauth2 *grpc.OAuth2ClientCredentials
+	if auth.OAuth2 != nil {
+		oauth2 = &grpc.OAuth2ClientCredentials{
+			TokenURL:     expand(auth.OAuth2.TokenURL),
+			ClientID:     expand(auth.OAuth2.ClientID),
+			ClientSecret: expand(auth.OAuth2.ClientSecret),
+			Scopes:       auth.OAuth2.Scopes,
+		}
+	}
+
+	providers, err := tokenProviders(expand(auth.Token), expand(auth.Exec), oauth2)
+	if err != nil {
+		return nil, fmt.Errorf("profile %q: %w", profileName, err)
+	}
+	if len(providers) != 1 {
+		return nil, fmt.Errorf("profile %q: auth must set exactly one of token, exec and oauth2", profileName)
+	}
+	return providers[0], nil
+}
+
+// tokenProviders returns a provider for each of the token, the token command
+// and the OAuth2 client that is set. The token command is split like a shell
+// command line so quoted arguments stay whole.
+func tokenProviders(token, exec string, oauth2 *grpc.OAuth2ClientCredentials) ([]grpc.TokenProvider, error) {
+	var providers []grpc.TokenProvider
+	if token != "" {
+		providers = append(providers, &grpc.StaticToken{Value: token})
+	}
+	if exec != "" {
+		command, err := export.SplitCommand(exec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token command: %w", err)
+		}
+		providers = append(providers, &grpc.ExecToken{Command: command})
+	}
+	if oauth2 != nil {
+		providers = append(providers, oauth2)
+	}
+	return providers, nil
+}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+
+	flags.IntVarP(&port, "port", "p", 50051, "grpc server port")
+	flags.StringVarP(&addr, "addr", "a", "", "grpc server address (unix:///path.sock and unix-abstract:name dial a unix socket)")
+	flags.StringVar(&protoset, "protoset", "", "path to protoset file (uses server reflection if not specified, starts offline if the server is unreachable)")
+	flags.StringSliceVar(&protoFiles, "proto", nil, "proto files to parse for descriptors instead of using server reflection")
+	flags.StringSliceVar(&importPaths, "import-path", nil, "directories to resolve imports of --proto files against")
+	flags.BoolVar(&useTLS, "tls", false, "use TLS to connect to the server")
+	flags.BoolVar(&unix, "unix", false, "treat --addr as a unix domain socket path")
+	flags.DurationVar(&timeout, "timeout", 10*time.Second, "connection timeout")
+	flags.BoolVar(&offline, "offline", false, "browse the --protoset or cached schema without connecting; connects on the first call")
+	flags.BoolVar(&noCache, "no-cache", false, "do not read or write the on-disk reflection cache")
+
+	flags.IntVar(&maxRecvMsgSize, "max-recv-msg-size", 0, "maximum response message size in bytes (grpc default is 4MB)")
+	flags.IntVar(&maxSendMsgSize, "max-send-msg-size", 0, "maximum request message size in bytes")
+	flags.DurationVar(&keepaliveTime, "keepalive-time", 0, "send keepalive pings after this much inactivity (disabled if 0)")
+	flags.DurationVar(&keepaliveTimeout, "keepalive-timeout", 20*time.Second, "wait this long for a keepalive ping ack before closing the connection")
+	flags.StringVar(&authority, "authority", "", "override the :authority header")
+	flags.StringArrayVarP(&headers, "header", "H", nil, `metadata sent with every call as "name: value"`)
+	flags.BoolVar(&useGzip, "gzip", false, "compress requests with gzip")
+	flags.Int32Var(&initialWindowSize, "initial-window-size", 0, "initial HTTP/2 stream window size in bytes")
+	flags.Int32Var(&initialConnWindowSize, "initial-conn-window-size", 0, "initial HTTP/2 connection window size in bytes")
+
+	flags.StringVar(&token, "token", "", "static bearer token sent with every call, overrides the profile's auth")
+	flags.StringVar(&tokenExec, "token-exec", "", "command that prints a bearer token (plain or JSON with token and expiry)")
+	flags.StringVar(&oauth2TokenURL, "oauth2-token-url", "", "OAuth2 token URL for the client credentials flow")
+	flags.StringVar(&oauth2ClientID, "oauth2-client-id", "", "OAuth2 client id")
+	flags.StringVar(&oauth2ClientSecret, "oauth2-client-secret", "", "OAuth2 client secret")
+	flags.StringSliceVar(&oauth2Scopes, "oauth2-scopes", nil, "OAuth2 scopes to request")
+	flags.BoolVar(&revealTokens, "reveal-tokens", false, "include real bearer tokens in copied grpcurl commands")
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var exportProtosetCmd = &cobra.Command{
+	Use:   "export-protoset <file>",
+	Short: "write the server's descriptors to a protoset file",
+	Long:  `fetches every descriptor over server reflection and writes them to a protoset file for use with --protoset`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := clientConfig()
+		if err != nil {
+			return err
+		}
+		// skip the cache so the export matches the live server
+		config.CacheDir = ""
+
+		grpcClient, err := connect(config)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		if err := grpcClient.WriteProtoset(f); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("failed to export protoset: %w", err)
+		}
+		return f.Close()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportProtosetCmd)
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/prnvbn/grpcexp/internal/collection"
+	"github.com/prnvbn/grpcexp/internal/importer"
+	"github.com/prnvbn/grpcexp/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importOutput string
+	saveImported bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <export>...",
+	Short: "convert Postman and Insomnia exports into a collection",
+	Long: `converts the gRPC requests of Postman collections and Insomnia v4 JSON exports into a collection for grpcexp replay, written to --output or printed.
+collection variables and Insomnia's base environment become the vars of the collection.
+Postman environment exports and Insomnia's other environments become profiles, which are printed or, with --save-profiles, added to the --profiles file for use with --profile.
+anything that can't be converted, such as HTTP requests, scripts and dynamic variables without a placeholder, is reported.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runImport,
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	var result importer.Result
+	for _, path := range args {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		r, err := importer.Read(f)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		result.Merge(r)
+	}
+
+	errOut := cmd.ErrOrStderr()
+	for _, skipped := range result.Skipped {
+		fmt.Fprintf(errOut, "skipped %s\n", skipped)
+	}
+	if len(result.Profiles) > 0 {
+		var err error
+		if saveImported {
+			err = saveProfiles(errOut, result.Profiles)
+		} else {
+			fmt.Fprintln(errOut, "imported profiles, add them to the profiles file with --save-profiles:")
+			err = profile.Write(errOut, result.Profiles)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	c := result.Collection
+	if len(c.Requests) == 0 && len(c.Vars) == 0 {
+		return nil
+	}
+	if importOutput == "" {
+		return collection.Write(cmd.OutOrStdout(), c)
+	}
+	if err := collection.Save(importOutput, c); err != nil {
+		return err
+	}
+	fmt.Fprintf(errOut, "imported %d requests into %s\n", len(c.Requests), importOutput)
+	return nil
+}
+
+// saveProfiles adds imported profiles to the profiles file, replacing the
+// ones with the same names.
+func saveProfiles(out io.Writer, imported profile.Profiles) error {
+	path, err := profilesPath()
+	if err != nil {
+		return err
+	}
+	profiles, err := profile.Load(path)
+	if err != nil {
+		return err
+	}
+	if profiles == nil {
+		profiles = profile.Profiles{}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(imported)) {
+		action := "added"
+		if _, ok := profiles[name]; ok {
+			action = "replaced"
+		}
+		profiles[name] = imported[name]
+		fmt.Fprintf(out, "%s profile %s in %s\n", action, name, path)
+	}
+	return profile.Save(path, profiles)
+}
+
+func init() {
+	importCmd.Flags().StringVarP(&importOutput, "output", "o", "", "collection file to write (printed when unset)")
+	importCmd.Flags().BoolVar(&saveImported, "save-profiles", false, "add imported profiles to the --profiles file instead of printing them")
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/prnvbn/grpcexp/internal/mock"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var (
+	mockListen    string
+	mockStubsFile string
+)
+
+var mockCmd = &cobra.Command{
+	Use:   "mock",
+	Short: "serve a mock of the server's services",
+	Long: `serves every service from --protoset, --proto or the reflection of a live server, with reflection enabled.
+calls are answered from the --stubs file, a JSON object of canned responses per method:
+
+  {
+    "helloworld.Greeter.SayHello": [
+      {"match": {"name": "Pranav"}, "response": {"message": "Hi Pranav"}},
+      {"error": {"code": "NotFound", "message": "no such user"}, "delay": "200ms"}
+    ],
+    "hello.v1.HelloService.HelloStream": [
+      {"responses": [{"message": "Hello"}, {"message": "again"}]}
+    ]
+  }
+
+the first stub whose match fits the request answers it; calls without a matching stub get sample data`,
+	Args: cobra.NoArgs,
+	RunE: runMock,
+}
+
+func runMock(cmd *cobra.Command, args []string) error {
+	var stubs mock.Stubs
+	if mockStubsFile != "" {
+		var err error
+		stubs, err = mock.LoadStubs(mockStubsFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	config, err := clientConfig()
+	if err != nil {
+		return err
+	}
+	if config.Protoset != "" || len(config.ProtoFiles) > 0 {
+		// local descriptors don't need the real server
+		config.Offline = true
+	} else {
+		// skip the cache so the mock matches the live server
+		config.CacheDir = ""
+	}
+
+	grpcClient, err := connect(config)
+	if err != nil {
+		return err
+	}
+	files, err := grpcClient.Files()
+	_ = grpcClient.Close()
+	if err != nil {
+		return fmt.Errorf("failed to load descriptors: %w", err)
+	}
+
+	mockServer, err := mock.NewServer(files, stubs)
+	if err != nil {
+		return err
+	}
+	logOut := cmd.ErrOrStderr()
+	mockServer.Logf = func(format string, args ...any) {
+		fmt.Fprintf(logOut, format+"\n", args...)
+	}
+
+	lis, err := net.Listen("tcp", mockListen)
+	if err != nil {
+		return err
+	}
+	s := grpc.NewServer()
+	mockServer.Register(s)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		s.Stop()
+	}()
+
+	fmt.Fprintf(logOut, "serving %s on %s\n", strings.Join(mockServer.Services(), ", "), lis.Addr())
+	return s.Serve(lis)
+}
+
+func init() {
+	mockCmd.Flags().StringVar(&mockListen, "listen", ":50052", "address to serve the mock on")
+	mockCmd.Flags().StringVar(&mockStubsFile, "stubs", "", "JSON file of canned responses per method")
+	rootCmd.AddCommand(mockCmd)
+}
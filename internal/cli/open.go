@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/prnvbn/grpcexp/internal/export"
+	"github.com/prnvbn/grpcexp/internal/grpc"
+	"github.com/prnvbn/grpcexp/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open [grpcurl command]",
+	Short: "open a grpcurl command in the explorer",
+	Long: `connects with the settings of a grpcurl command and opens its method with the request builder filled from -d.
+the command is read from stdin when it isn't given, and is best quoted or passed after -- so its flags aren't read as grpcexp's:
+
+  grpcexp open "grpcurl -plaintext -d '{\"name\": \"pranav\"}' localhost:50051 helloworld.Greeter/SayHello"
+  grpcexp open -- grpcurl -plaintext -H 'x-user: pranav' localhost:50051 helloworld.Greeter/SayHello
+  pbpaste | grpcexp open
+
+-d, -H, -rpc-header, -reflect-header, -plaintext, -unix, -protoset, -proto, -import-path, -authority, -user-agent, -max-msg-sz and -keepalive-time are used, other grpcurl flags are reported and ignored.`,
+	RunE: runOpen,
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	command, err := grpcurlArgs(cmd, args)
+	if err != nil {
+		return err
+	}
+	r, ignored, err := export.ParseGRPCURL(command)
+	if err != nil {
+		return err
+	}
+	for _, flag := range ignored {
+		fmt.Fprintf(cmd.ErrOrStderr(), "ignoring %s\n", flag)
+	}
+
+	vars, err := templateVars()
+	if err != nil {
+		return err
+	}
+
+	config, err := openConfig(r)
+	if err != nil {
+		return err
+	}
+
+	grpcClient, err := connect(config)
+	if err != nil {
+		return err
+	}
+	method, err := grpcClient.FindMethod(r.Method)
+	if err != nil {
+		return err
+	}
+
+	return explore(grpcClient, vars, func(m *tui.Model) {
+		m.Open(method, r.Body)
+	})
+}
+
+// openConfig returns the config for the settings of r. Flags of grpcexp that r
+// has no equivalent for, such as the token flags, still apply.
+func openConfig(r export.Request) (grpc.Config, error) {
+	if r.Protoset != "" && len(r.ProtoFiles) > 0 {
+		return grpc.Config{}, fmt.Errorf("only one of -protoset and -proto may be set")
+	}
+	config, err := clientConfig()
+	if err != nil {
+		return grpc.Config{}, err
+	}
+
+	config.Network, config.Target = grpc.ParseTarget(r.Target)
+	if r.Unix {
+		config.Network = grpc.NetworkUnix
+	}
+	config.Creds = transportCreds(!r.Plaintext)
+	config.Protoset, config.ProtoFiles, config.ImportPaths = r.Protoset, r.ProtoFiles, r.ImportPaths
+	config.Headers = append(slices.Clone(config.Headers), r.Headers...)
+	config.RPCHeaders, config.ReflectHeaders = r.RPCHeaders, r.ReflectHeaders
+	if r.Authority != "" {
+		config.Authority = r.Authority
+	}
+	if r.UserAgent != "" {
+		config.UserAgent = r.UserAgent
+	}
+	if r.MaxRecvMsgSize > 0 {
+		config.MaxRecvMsgSize = r.MaxRecvMsgSize
+	}
+	if r.KeepaliveTime > 0 {
+		config.KeepaliveTime = r.KeepaliveTime
+	}
+	return config, nil
+}
+
+// grpcurlArgs returns the grpcurl command given as one quoted argument, as
+// separate arguments or on stdin.
+func grpcurlArgs(cmd *cobra.Command, args []string) ([]string, error) {
+	switch len(args) {
+	case 0:
+		line, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read grpcurl command: %w", err)
+		}
+		return export.SplitCommand(string(line))
+	case 1:
+		return export.SplitCommand(args[0])
+	default:
+		return args, nil
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+}
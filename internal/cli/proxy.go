@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/collection"
+	"github.com/prnvbn/grpcexp/internal/grpc"
+	"github.com/prnvbn/grpcexp/internal/proxy"
+	"github.com/prnvbn/grpcexp/internal/tui/call"
+	"github.com/spf13/cobra"
+)
+
+var (
+	proxyListen   string
+	proxyUpstream string
+	proxyHeadless bool
+	proxySaveFile string
+)
+
+var proxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "record the calls passing through a proxy to the server",
+	Long: `listens on --listen and forwards every call to --upstream (the --addr or --port server when unset),
+decoding messages with --protoset, --proto or the upstream's reflection.
+calls keep the caller's own metadata, --token and the other auth flags only apply to reflection.
+calls are shown live as they happen; --headless prints each finished call as a JSON line instead.
+finished calls can be saved as a collection of requests with ctrl+s or --save and sent again with grpcexp replay`,
+	Args: cobra.NoArgs,
+	RunE: runProxy,
+}
+
+func runProxy(cmd *cobra.Command, args []string) error {
+	config, err := clientConfig()
+	if err != nil {
+		return err
+	}
+	if proxyUpstream != "" {
+		config.Network, config.Target = grpc.ParseTarget(proxyUpstream)
+	}
+	if config.Protoset == "" && len(config.ProtoFiles) == 0 {
+		// skip the cache so messages are decoded with the live schema
+		config.CacheDir = ""
+	}
+
+	grpcClient, err := connect(config)
+	if err != nil {
+		return err
+	}
+	defer grpcClient.Close() //nolint:errcheck // closed on exit
+	files, err := grpcClient.Files()
+	if err != nil {
+		return fmt.Errorf("failed to load descriptors: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	upstream, err := grpcClient.ForwardConn(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	defer upstream.Close() //nolint:errcheck // closed on exit
+
+	lis, err := net.Listen("tcp", proxyListen)
+	if err != nil {
+		return err
+	}
+	p := proxy.New(upstream, files)
+	if proxyHeadless {
+		p.OnDone = logCall(cmd)
+	}
+	s := p.NewServer()
+	served := make(chan error, 1)
+	go func() { served <- s.Serve(lis) }()
+	defer s.Stop()
+
+	if proxyHeadless {
+		err = proxyHeadlessLog(cmd, lis.Addr().String(), grpcClient.Target(), served)
+	} else {
+		_, err = tea.NewProgram(call.NewProxyViewer(p, lis.Addr().String(), grpcClient.Target()), tea.WithAltScreen()).Run()
+	}
+	if err != nil {
+		return err
+	}
+
+	if proxySaveFile != "" {
+		var finished []proxy.Call
+		for _, c := range p.Calls() {
+			if c.Done() {
+				finished = append(finished, c)
+			}
+		}
+		c := proxy.Collection(grpcClient.Target(), finished)
+		if err := collection.Save(proxySaveFile, c); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "saved %d requests to %s\n", len(c.Requests), proxySaveFile)
+	}
+	return nil
+}
+
+// logCall returns a callback printing each finished call as a JSON line.
+func logCall(cmd *cobra.Command) func(proxy.Call) {
+	var mu sync.Mutex
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	return func(c proxy.Call) {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = enc.Encode(c)
+	}
+}
+
+// proxyHeadlessLog waits while the proxy serves until interrupted.
+func proxyHeadlessLog(cmd *cobra.Command, listen, upstream string, served <-chan error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Fprintf(cmd.ErrOrStderr(), "proxying %s to %s\n", listen, upstream)
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-served:
+		return err
+	}
+}
+
+func init() {
+	proxyCmd.Flags().StringVar(&proxyListen, "listen", ":6000", "address to serve the proxy on")
+	proxyCmd.Flags().StringVar(&proxyUpstream, "upstream", "", "server to forward calls to, defaults to --addr or --port")
+	proxyCmd.Flags().BoolVar(&proxyHeadless, "headless", false, "print each finished call as a JSON line instead of showing them")
+	proxyCmd.Flags().StringVar(&proxySaveFile, "save", "", "write the finished calls to this collection file on exit")
+	rootCmd.AddCommand(proxyCmd)
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/prnvbn/grpcexp/internal/collection"
+	"github.com/prnvbn/grpcexp/internal/grpc"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/metadata"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <collection>",
+	Short: "send the requests in a collection",
+	Long: `sends each request of a collection file, such as one saved by grpcexp proxy, in order with its metadata and prints the responses.
+a request can capture values from its response into variables that later requests use as {{name}}:
+
+  requests:
+    - method: session.v1.SessionService.Create
+      capture: {session_id: $.session.id}
+    - method: session.v1.SessionService.Get
+      metadata: {x-session: "{{session_id}}"}
+      body: {id: "{{session_id}}"}
+
+vars of the collection set defaults that --profile and --var override before the first request.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReplay,
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	c, err := collection.Load(args[0])
+	if err != nil {
+		return err
+	}
+	vars := collection.Vars{}
+	maps.Copy(vars, c.Vars)
+	overrides, err := templateVars()
+	if err != nil {
+		return err
+	}
+	maps.Copy(vars, overrides)
+
+	grpcClient, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer grpcClient.Close() //nolint:errcheck // closed on exit
+
+	out := cmd.OutOrStdout()
+	failed := 0
+	for i, req := range c.Requests {
+		name := req.Name
+		if name == "" {
+			name = req.Method
+		}
+		fmt.Fprintf(out, "== %s\n", name)
+
+		responses, err := replay(grpcClient, req.Expand(vars, i+1))
+		for _, response := range responses {
+			fmt.Fprintln(out, response)
+		}
+		if err == nil && len(req.Capture) > 0 {
+			// streams capture from their last response
+			last := ""
+			if len(responses) > 0 {
+				last = responses[len(responses)-1]
+			}
+			err = req.CaptureVars(last, vars)
+		}
+		if err != nil {
+			fmt.Fprintf(out, "error: %v\n", err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d requests failed", failed, len(c.Requests))
+	}
+	return nil
+}
+
+// replay sends req, which may be a call of any kind, and returns the responses.
+func replay(grpcClient *grpc.Client, req collection.Request) ([]string, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, metadata.New(req.Metadata))
+
+	messages := req.Messages
+	if len(messages) == 0 {
+		body := req.Body
+		if body == nil {
+			body = map[string]any{}
+		}
+		messages = []map[string]any{body}
+	}
+	requests := make(chan map[string]any, len(messages))
+	for _, msg := range messages {
+		requests <- msg
+	}
+	close(requests)
+
+	events := make(chan grpc.StreamEvent, 16)
+	go func() {
+		_ = grpcClient.InvokeStreaming(ctx, req.Method, requests, events)
+	}()
+
+	var responses []string
+	for event := range events {
+		switch event.Kind {
+		case grpc.StreamEventResponse:
+			responses = append(responses, event.Message)
+		case grpc.StreamEventError:
+			return responses, event.Err
+		case grpc.StreamEventClosed:
+			return responses, nil
+		}
+	}
+	return responses, nil
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+}
@@ -1,28 +1,16 @@
 package cli
 
 import (
-	"context"
-	"crypto/tls"
-	"fmt"
 	"os"
-	"strings"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/prnvbn/grpcexp/internal/grpc"
 	"github.com/prnvbn/grpcexp/internal/tui"
+	"github.com/prnvbn/grpcexp/internal/tui/call"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
-var (
-	port     int
-	addr     string
-	protoset string
-	useTLS   bool
-	timeout  time.Duration
-)
+var transcriptLimit int
 
 var rootCmd = &cobra.Command{
 	Use:          "grpcexp",
@@ -33,36 +21,29 @@ var rootCmd = &cobra.Command{
 }
 
 func run(cmd *cobra.Command, args []string) error {
-	var target string
-	if addr != "" {
-		target = addr
-	} else {
-		target = fmt.Sprintf("localhost:%d", port)
-	}
-
-	var creds credentials.TransportCredentials
-	if useTLS {
-		creds = credentials.NewTLS(&tls.Config{})
-	} else {
-		creds = insecure.NewCredentials()
+	vars, err := templateVars()
+	if err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-	grpcClient, err := grpc.NewClient(ctx, grpc.Config{
-		Target:    target,
-		Creds:     creds,
-		UserAgent: "grpcexp/" + strings.TrimSpace(version),
-		Protoset:  protoset,
-	})
+	grpcClient, err := newClient()
 	if err != nil {
 		return err
 	}
 
-	m, err := tui.NewModel(grpcClient)
+	return explore(grpcClient, vars, nil)
+}
+
+// explore runs the explorer until it is quit. setup, if set, adjusts the model
+// before it starts.
+func explore(grpcClient *grpc.Client, vars map[string]any, setup func(m *tui.Model)) error {
+	m, err := tui.NewModel(grpcClient, call.Options{TranscriptLimit: transcriptLimit, Vars: vars})
 	if err != nil {
 		return err
 	}
+	if setup != nil {
+		setup(&m)
+	}
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
@@ -72,17 +53,13 @@ func run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func init() {
+	rootCmd.Flags().IntVar(&transcriptLimit, "transcript-limit", 10000, "maximum entries kept in a stream transcript, 0 for no limit")
+}
+
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
 		os.Exit(1)
 	}
 }
-
-func init() {
-	rootCmd.Flags().IntVarP(&port, "port", "p", 50051, "grpc server port")
-	rootCmd.Flags().StringVarP(&addr, "addr", "a", "", "grpc server address")
-	rootCmd.Flags().StringVar(&protoset, "protoset", "", "path to protoset file (uses server reflection if not specified)")
-	rootCmd.Flags().BoolVar(&useTLS, "tls", false, "use TLS to connect to the server")
-	rootCmd.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "connection timeout")
-}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/prnvbn/grpcexp/internal/grpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	streamDataFile string
+	streamDelay    time.Duration
+)
+
+var streamCmd = &cobra.Command{
+	Use:   "stream <method>",
+	Short: "send newline-delimited JSON messages to a streaming method",
+	Long:  `reads one JSON request per line from --data-file (or stdin) and sends them in order, printing each response as it arrives`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runStream,
+}
+
+func runStream(cmd *cobra.Command, args []string) error {
+	var in io.Reader = os.Stdin
+	if streamDataFile != "" && streamDataFile != "-" {
+		f, err := os.Open(streamDataFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close() //nolint:errcheck // read-only file
+		in = f
+	}
+
+	messages, err := grpc.ReadNDJSON(in)
+	if err != nil {
+		return fmt.Errorf("failed to read messages: %w", err)
+	}
+
+	grpcClient, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := make(chan map[string]any)
+	events := make(chan grpc.StreamEvent, 16)
+	go func() {
+		_ = grpcClient.InvokeStreaming(ctx, args[0], requests, events)
+	}()
+
+	go func() {
+		defer close(requests)
+		for i, msg := range messages {
+			if i > 0 && streamDelay > 0 {
+				time.Sleep(streamDelay)
+			}
+			select {
+			case requests <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	out := cmd.OutOrStdout()
+	for event := range events {
+		switch event.Kind {
+		case grpc.StreamEventResponse:
+			if _, err := fmt.Fprintln(out, event.Message); err != nil {
+				return err
+			}
+		case grpc.StreamEventError:
+			return event.Err
+		case grpc.StreamEventClosed:
+			return nil
+		}
+	}
+	return nil
+}
+
+func init() {
+	streamCmd.Flags().StringVarP(&streamDataFile, "data-file", "f", "-", "newline-delimited JSON file of requests (- reads stdin)")
+	streamCmd.Flags().DurationVar(&streamDelay, "delay", 0, "delay between sent messages")
+	rootCmd.AddCommand(streamCmd)
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prnvbn/grpcexp/internal/suite"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testFormat      string
+	testOutput      string
+	testCallTimeout time.Duration
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test <file>...",
+	Short: "run the unary calls in YAML test files and check their results",
+	Long: `runs each request in the test files in order and checks it against its expectations:
+
+  name: greeter
+  vars: {user: Pranav}  # variables, overridden by --profile and --var
+  requests:
+    - name: says hello
+      method: helloworld.Greeter.SayHello
+      metadata: {x-request-id: "42"}
+      body: {name: "{{user}}"}
+      expect:
+        response: {message: Hello Pranav, time: ""}  # the whole response
+        ignore: [$.time]                              # paths left out of response
+        paths: {$.message: Hello Pranav}              # values at paths
+        regex: {$.message: ^Hello}                    # patterns values at paths match
+      capture: {greeting: $.message}                  # variables for later requests
+    - method: helloworld.Greeter.SayHello
+      metadata: {x-greeting: "{{greeting}}"}
+      body: {name: ""}
+      expect:
+        status: INVALID_ARGUMENT  # OK when unset
+        message: name             # pattern the status message matches
+
+collections are test files whose requests are expected to succeed.
+results are written as text, TAP or JUnit XML; the command fails if any test does`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runTest,
+}
+
+func runTest(cmd *cobra.Command, args []string) error {
+	write, err := testWriter(testFormat)
+	if err != nil {
+		return err
+	}
+
+	vars, err := templateVars()
+	if err != nil {
+		return err
+	}
+
+	suites := make([]suite.Suite, len(args))
+	for i, path := range args {
+		s, err := suite.Load(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if s.Name == "" {
+			s.Name = filepath.Base(path)
+		}
+		// variables from the command line take precedence
+		if s.Vars == nil {
+			s.Vars = map[string]any{}
+		}
+		maps.Copy(s.Vars, vars)
+		suites[i] = s
+	}
+
+	grpcClient, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer grpcClient.Close() //nolint:errcheck // closed on exit
+
+	var reports []suite.Report
+	total, failed := 0, 0
+	for _, s := range suites {
+		report := suite.Run(context.Background(), grpcClient.InvokeRPC, s, testCallTimeout)
+		reports = append(reports, report)
+		total += len(report.Results)
+		failed += report.Failed()
+	}
+
+	out := cmd.OutOrStdout()
+	if testOutput != "" && testOutput != "-" {
+		f, err := os.Create(testOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close() //nolint:errcheck // closed on exit
+		out = f
+	}
+	if err := write(out, reports); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tests failed", failed, total)
+	}
+	return nil
+}
+
+func testWriter(format string) (func(io.Writer, []suite.Report) error, error) {
+	switch format {
+	case "text":
+		return suite.WriteText, nil
+	case "tap":
+		return suite.WriteTAP, nil
+	case "junit":
+		return suite.WriteJUnit, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q, want text, tap or junit", format)
+	}
+}
+
+func init() {
+	testCmd.Flags().StringVar(&testFormat, "format", "text", "result format: text, tap or junit")
+	testCmd.Flags().StringVarP(&testOutput, "output", "o", "-", "file to write results to (- writes stdout)")
+	testCmd.Flags().DurationVar(&testCallTimeout, "call-timeout", 30*time.Second, "maximum time for each call, 0 for no limit")
+	rootCmd.AddCommand(testCmd)
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/transcript"
+	"github.com/prnvbn/grpcexp/internal/tui/call"
+	"github.com/spf13/cobra"
+)
+
+var transcriptCmd = &cobra.Command{
+	Use:   "transcript <file>",
+	Short: "view a saved stream transcript",
+	Long:  `opens a transcript saved from a stream screen, as NDJSON or JSON, in a read-only viewer`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		log, err := transcript.Read(f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+
+		p := tea.NewProgram(call.NewTranscriptViewer(filepath.Base(args[0]), log), tea.WithAltScreen())
+		_, err = p.Run()
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(transcriptCmd)
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/prnvbn/grpcexp/internal/profile"
+)
+
+var (
+	profileName  string
+	profilesFile string
+	varFlags     []string
+)
+
+// templateVars returns the variables request placeholders resolve against:
+// those of the selected profile, overridden by --var.
+func templateVars() (map[string]any, error) {
+	p, err := selectedProfile()
+	if err != nil {
+		return nil, err
+	}
+	vars := map[string]any{}
+	maps.Copy(vars, p.Vars)
+
+	for _, v := range varFlags {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --var %q, want name=value", v)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// selectedProfile returns the --profile profile, or an empty one when no
+// profile is selected.
+func selectedProfile() (profile.Profile, error) {
+	if profileName == "" {
+		return profile.Profile{}, nil
+	}
+	path, err := profilesPath()
+	if err != nil {
+		return profile.Profile{}, err
+	}
+	profiles, err := profile.Load(path)
+	if err != nil {
+		return profile.Profile{}, err
+	}
+	p, err := profiles.Get(profileName)
+	if err != nil {
+		return profile.Profile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// profilesPath returns the --profiles file or the default one.
+func profilesPath() (string, error) {
+	if profilesFile != "" {
+		return profilesFile, nil
+	}
+	path, err := profile.DefaultPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the profiles file: %w", err)
+	}
+	return path, nil
+}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+
+	flags.StringVar(&profileName, "profile", "", "profile whose variables fill {{name}} placeholders in requests and whose auth is used without auth flags")
+	flags.StringVar(&profilesFile, "profiles", "", "profiles file (default $XDG_CONFIG_HOME/grpcexp/profiles.yaml)")
+	flags.StringArrayVar(&varFlags, "var", nil, "variable filling {{name}} placeholders in requests as name=value, overrides the profile")
+}
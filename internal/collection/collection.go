@@ -0,0 +1,157 @@
+// Package collection reads and writes collections of saved requests as YAML.
+package collection
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/prnvbn/grpcexp/internal/jsonpath"
+	"github.com/prnvbn/grpcexp/internal/placeholder"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Collection is a named list of requests that can be replayed.
+type Collection struct {
+	Name string `yaml:"name,omitempty"`
+	// Vars are the default values of the variables requests use, overridden
+	// by profiles and captures.
+	Vars     map[string]any `yaml:"vars,omitempty"`
+	Requests []Request      `yaml:"requests"`
+}
+
+type Request struct {
+	Name string `yaml:"name,omitempty"`
+	// Method is the full method name, e.g. helloworld.Greeter.SayHello.
+	Method   string            `yaml:"method"`
+	Metadata map[string]string `yaml:"metadata,omitempty"`
+	// Body is the request message of unary and server streaming calls.
+	Body map[string]any `yaml:"body,omitempty"`
+	// Messages are the request messages of client and bidi streaming calls.
+	Messages []map[string]any `yaml:"messages,omitempty"`
+	// Capture maps variable names to paths, such as $.session.id, of values
+	// in the response. Later requests use them as {{name}} in their body,
+	// messages and metadata.
+	Capture map[string]string `yaml:"capture,omitempty"`
+}
+
+// Vars holds the values captured from responses by name.
+type Vars map[string]any
+
+// Expand returns req with the placeholders in its body, messages and
+// metadata resolved, variables included.
+func (r Request) Expand(vars Vars, seq int) Request {
+	ctx := placeholder.Context{Seq: seq, Vars: vars}
+	if r.Body != nil {
+		r.Body = placeholder.Expand(r.Body, ctx)
+	}
+	if r.Messages != nil {
+		messages := make([]map[string]any, len(r.Messages))
+		for i, msg := range r.Messages {
+			messages[i] = placeholder.Expand(msg, ctx)
+		}
+		r.Messages = messages
+	}
+	if r.Metadata != nil {
+		md := make(map[string]string, len(r.Metadata))
+		for k, v := range r.Metadata {
+			md[k] = placeholder.ExpandString(v, ctx)
+		}
+		r.Metadata = md
+	}
+	return r
+}
+
+// CaptureVars stores the values the request captures from response, a JSON
+// message, in vars.
+func (r Request) CaptureVars(response string, vars Vars) error {
+	if len(r.Capture) == 0 {
+		return nil
+	}
+
+	var doc any
+	if err := json.Unmarshal([]byte(response), &doc); err != nil {
+		return fmt.Errorf("failed to capture variables: response is not JSON: %w", err)
+	}
+	names := make([]string, 0, len(r.Capture))
+	for name := range r.Capture {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path, err := jsonpath.Parse(r.Capture[name])
+		if err != nil {
+			return fmt.Errorf("failed to capture %s: %w", name, err)
+		}
+		value, ok := path.Get(doc)
+		if !ok {
+			return fmt.Errorf("failed to capture %s: %s not found in response", name, path)
+		}
+		vars[name] = value
+	}
+	return nil
+}
+
+// Read reads a collection. JSON is read as well since it is valid YAML.
+func Read(r io.Reader) (Collection, error) {
+	var c Collection
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil && err != io.EOF {
+		return Collection{}, fmt.Errorf("failed to read collection: %w", err)
+	}
+	for i, req := range c.Requests {
+		if err := req.Validate(); err != nil {
+			return Collection{}, fmt.Errorf("failed to read collection: request %d: %w", i+1, err)
+		}
+	}
+	return c, nil
+}
+
+// Validate checks that the request has a method and valid capture paths.
+func (r Request) Validate() error {
+	if r.Method == "" {
+		return fmt.Errorf("no method")
+	}
+	for name, path := range r.Capture {
+		if _, err := jsonpath.Parse(path); err != nil {
+			return fmt.Errorf("capture %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func Write(w io.Writer, c Collection) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return fmt.Errorf("failed to write collection: %w", err)
+	}
+	return enc.Close()
+}
+
+// Load reads the collection in the file at path.
+func Load(path string) (Collection, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Collection{}, err
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+	return Read(f)
+}
+
+// Save writes c to the file at path.
+func Save(path string, c Collection) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, c); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
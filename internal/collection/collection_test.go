@@ -0,0 +1,85 @@
+package collection
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	want := Collection{
+		Name: "captured",
+		Vars: map[string]any{"user": "Pranav"},
+		Requests: []Request{
+			{
+				Method:   "helloworld.Greeter.SayHello",
+				Metadata: map[string]string{"x-request-id": "1"},
+				Body:     map[string]any{"name": "Pranav", "tags": []any{"a", "b"}},
+			},
+			{
+				Name:     "chat",
+				Method:   "hello.v1.HelloService.HelloStream",
+				Messages: []map[string]any{{"name": "a"}, {"name": "b"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, want); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Read = %#v, want %#v", got, want)
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	got, err := Read(strings.NewReader(`{"requests": [{"method": "echo.v1.EchoService.Echo", "body": {"message": "hi"}}]}`))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if len(got.Requests) != 1 || got.Requests[0].Body["message"] != "hi" {
+		t.Fatalf("Read = %#v", got)
+	}
+}
+
+func TestReadRequiresMethod(t *testing.T) {
+	_, err := Read(strings.NewReader("requests:\n  - body: {}\n"))
+	if err == nil || !strings.Contains(err.Error(), "request 1: no method") {
+		t.Fatalf("Read error = %v, want missing method", err)
+	}
+}
+
+func TestCaptureAndExpand(t *testing.T) {
+	create := Request{Method: "a.B.Create", Capture: map[string]string{"session_id": "$.session.id", "n": "count"}}
+	vars := Vars{}
+	if err := create.CaptureVars(`{"session": {"id": "s-1"}, "count": 2}`, vars); err != nil {
+		t.Fatalf("CaptureVars returned error: %v", err)
+	}
+	if vars["session_id"] != "s-1" || vars["n"] != float64(2) {
+		t.Fatalf("vars = %v", vars)
+	}
+
+	get := Request{
+		Method:   "a.B.Get",
+		Metadata: map[string]string{"x-session": "{{session_id}}"},
+		Body:     map[string]any{"id": "{{session_id}}", "limit": "{{n}}"},
+		Messages: []map[string]any{{"note": "session {{session_id}}"}},
+	}
+	got := get.Expand(vars, 1)
+	if got.Metadata["x-session"] != "s-1" || got.Body["id"] != "s-1" || got.Body["limit"] != float64(2) || got.Messages[0]["note"] != "session s-1" {
+		t.Errorf("Expand = %+v", got)
+	}
+	if get.Body["id"] != "{{session_id}}" || get.Metadata["x-session"] != "{{session_id}}" {
+		t.Errorf("Expand modified the request: %+v", get)
+	}
+
+	if err := create.CaptureVars(`{"count": 2}`, vars); err == nil {
+		t.Error("CaptureVars with a missing path returned no error")
+	}
+}
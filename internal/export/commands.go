@@ -0,0 +1,159 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GRPCURL writes a grpcurl command.
+func GRPCURL(r Request) (string, error) {
+	body, err := r.body()
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"grpcurl"}
+	if r.Plaintext {
+		args = append(args, "-plaintext")
+	}
+	if r.Unix {
+		args = append(args, "-unix")
+	}
+	if r.Protoset != "" {
+		args = append(args, "-protoset", r.Protoset)
+	}
+	if len(r.ProtoFiles) > 0 {
+		for _, path := range r.ImportPaths {
+			args = append(args, "-import-path", path)
+		}
+		for _, file := range r.ProtoFiles {
+			args = append(args, "-proto", file)
+		}
+	}
+	if r.UserAgent != "" {
+		args = append(args, "-user-agent", r.UserAgent)
+	}
+	if r.Authority != "" {
+		args = append(args, "-authority", r.Authority)
+	}
+	if r.MaxRecvMsgSize > 0 {
+		args = append(args, "-max-msg-sz", strconv.Itoa(r.MaxRecvMsgSize))
+	}
+	if r.KeepaliveTime > 0 {
+		args = append(args, "-keepalive-time", strconv.FormatFloat(r.KeepaliveTime.Seconds(), 'f', -1, 64))
+	}
+	for _, h := range r.Headers {
+		args = append(args, "-H", h)
+	}
+	for _, h := range r.RPCHeaders {
+		args = append(args, "-rpc-header", h)
+	}
+	for _, h := range r.ReflectHeaders {
+		args = append(args, "-reflect-header", h)
+	}
+	args = append(args, "-d", body, r.Target, r.Method)
+	return command(args), nil
+}
+
+// BufCurl writes a buf curl command using the gRPC protocol.
+func BufCurl(r Request) (string, error) {
+	body, err := r.body()
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"buf", "curl", "--protocol", "grpc"}
+	if r.Plaintext {
+		args = append(args, "--http2-prior-knowledge")
+	}
+	if r.Unix {
+		args = append(args, "--unix-socket", r.Target)
+	}
+	if r.Protoset != "" {
+		args = append(args, "--schema", r.Protoset+"#format=binpb")
+	}
+	for _, file := range r.ProtoFiles {
+		args = append(args, "--schema", file)
+	}
+	if r.UserAgent != "" {
+		args = append(args, "--user-agent", r.UserAgent)
+	}
+	if r.Authority != "" {
+		args = append(args, "-H", "host: "+r.Authority)
+	}
+	for _, h := range r.callHeaders() {
+		args = append(args, "-H", h)
+	}
+	for _, h := range r.ReflectHeaders {
+		args = append(args, "--reflect-header", h)
+	}
+	args = append(args, "-d", body, r.url())
+	return command(args), nil
+}
+
+// GRPCCLI writes a grpc_cli call. grpc_cli reads schemas from proto files or
+// server reflection, so protosets are left out.
+func GRPCCLI(r Request) (string, error) {
+	body, err := r.body()
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"grpc_cli", "call", r.coreTarget(), r.Method, body, "--json_input", "--json_output"}
+	if r.Plaintext {
+		args = append(args, "--channel_creds_type=insecure")
+	} else {
+		args = append(args, "--channel_creds_type=ssl")
+	}
+	if len(r.ProtoFiles) > 0 {
+		args = append(args, "--protofiles="+strings.Join(r.ProtoFiles, ","))
+		if len(r.ImportPaths) > 0 {
+			args = append(args, "--proto_path="+strings.Join(r.ImportPaths, ":"))
+		}
+	}
+	if headers := r.callHeaders(); len(headers) > 0 {
+		// names and values are separated by colons, so colons in values are
+		// escaped
+		pairs := make([]string, len(headers))
+		for i, h := range headers {
+			name, value := header(h)
+			pairs[i] = name + ":" + strings.ReplaceAll(value, ":", `\:`)
+		}
+		args = append(args, "--metadata="+strings.Join(pairs, ":"))
+	}
+	return command(args), nil
+}
+
+// ConnectCurl writes a curl command calling a unary method with the JSON
+// encoding of the Connect protocol, for servers that serve Connect and
+// gRPC-Web next to gRPC.
+func ConnectCurl(r Request) (string, error) {
+	if client, server := r.streaming(); client || server {
+		return "", fmt.Errorf("connect curl only supports unary methods")
+	}
+	body, err := r.body()
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"curl"}
+	if r.Unix {
+		args = append(args, "--unix-socket", r.Target)
+	}
+	args = append(args,
+		"-H", "content-type: application/json",
+		"-H", "connect-protocol-version: 1",
+	)
+	if r.UserAgent != "" {
+		args = append(args, "-A", r.UserAgent)
+	}
+	if r.Authority != "" {
+		args = append(args, "-H", "host: "+r.Authority)
+	}
+	for _, h := range r.callHeaders() {
+		args = append(args, "-H", h)
+	}
+	args = append(args, "-d", body, r.url())
+	return command(args), nil
+}
@@ -0,0 +1,148 @@
+// Package export writes a call and the connection settings it is made with as
+// commands and code snippets for other tools, and reads grpcurl commands back.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Request is a call and the settings of the connection it is made on.
+type Request struct {
+	// Method is the full method name, e.g. helloworld.Greeter.SayHello.
+	Method string
+	// Desc describes the method. Formats that need the names of generated
+	// code fail without it.
+	Desc protoreflect.MethodDescriptor
+	Body map[string]any
+
+	Target string
+	// Unix is set when Target is a unix socket path.
+	Unix      bool
+	Plaintext bool
+	Authority string
+	UserAgent string
+	// Headers are sent with the call and with reflection as "name: value".
+	Headers []string
+	// RPCHeaders are only sent with the call and ReflectHeaders only with
+	// reflection.
+	RPCHeaders     []string
+	ReflectHeaders []string
+
+	Protoset    string
+	ProtoFiles  []string
+	ImportPaths []string
+
+	MaxRecvMsgSize int
+	KeepaliveTime  time.Duration
+}
+
+// Format writes requests for one tool.
+type Format struct {
+	// Name is shown when picking a format, e.g. "grpcurl".
+	Name   string
+	Export func(r Request) (string, error)
+}
+
+var formats = []Format{
+	{Name: "grpcurl", Export: GRPCURL},
+	{Name: "buf curl", Export: BufCurl},
+	{Name: "grpc_cli", Export: GRPCCLI},
+	{Name: "connect curl", Export: ConnectCurl},
+	{Name: "go", Export: Go},
+	{Name: "python", Export: Python},
+}
+
+// Formats returns the available formats, grpcurl first.
+func Formats() []Format {
+	return append([]Format(nil), formats...)
+}
+
+// Register makes another format available.
+func Register(f Format) {
+	formats = append(formats, f)
+}
+
+func (r Request) body() (string, error) {
+	body := r.Body
+	if body == nil {
+		body = map[string]any{}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+	return string(data), nil
+}
+
+// callHeaders are the headers sent with the call.
+func (r Request) callHeaders() []string {
+	return append(append([]string(nil), r.Headers...), r.RPCHeaders...)
+}
+
+// path is the HTTP path of the method, e.g. /helloworld.Greeter/SayHello.
+func (r Request) path() string {
+	i := strings.LastIndex(r.Method, ".")
+	if i < 0 {
+		return "/" + r.Method
+	}
+	return "/" + r.Method[:i] + "/" + r.Method[i+1:]
+}
+
+// url is the URL of the method for HTTP clients, which reach unix sockets
+// through a separate flag.
+func (r Request) url() string {
+	scheme := "https://"
+	if r.Plaintext {
+		scheme = "http://"
+	}
+	host := r.Target
+	if r.Unix {
+		host = "localhost"
+	}
+	return scheme + host + r.path()
+}
+
+// coreTarget is the target in the form of the gRPC core libraries.
+func (r Request) coreTarget() string {
+	if r.Unix {
+		return "unix:" + r.Target
+	}
+	return r.Target
+}
+
+func (r Request) streaming() (client, server bool) {
+	if r.Desc == nil {
+		return false, false
+	}
+	return r.Desc.IsStreamingClient(), r.Desc.IsStreamingServer()
+}
+
+// header splits a "name: value" header.
+func header(h string) (string, string) {
+	name, value, _ := strings.Cut(h, ":")
+	return strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(value)
+}
+
+// command joins args into a shell-safe command.
+func command(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_+-=.,/:@", r)
+	}) == -1 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", "'\"'\"'") + "'"
+}
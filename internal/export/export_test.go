@@ -0,0 +1,154 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	echov1 "github.com/prnvbn/grpcexp/cmd/testserver/echo"
+	hellov1 "github.com/prnvbn/grpcexp/cmd/testserver/hello"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func echoMethod(name protoreflect.Name) protoreflect.MethodDescriptor {
+	return echov1.File_cmd_testserver_echo_echo_proto.Services().Get(0).Methods().ByName(name)
+}
+
+func testRequest(method protoreflect.MethodDescriptor) Request {
+	return Request{
+		Method:    string(method.FullName()),
+		Desc:      method,
+		Body:      map[string]any{"message": "it's here"},
+		Target:    "localhost:50051",
+		Plaintext: true,
+		UserAgent: "grpcexp/test",
+		Headers:   []string{"authorization: Bearer <redacted>"},
+	}
+}
+
+func TestCommands(t *testing.T) {
+	r := testRequest(echoMethod("Echo"))
+	tests := []struct {
+		export func(Request) (string, error)
+		want   string
+	}{
+		{BufCurl, `buf curl --protocol grpc --http2-prior-knowledge --user-agent grpcexp/test -H 'authorization: Bearer <redacted>' -d '{"message":"it'"'"'s here"}' http://localhost:50051/echo.v1.EchoService/Echo`},
+		{GRPCCLI, `grpc_cli call localhost:50051 echo.v1.EchoService.Echo '{"message":"it'"'"'s here"}' --json_input --json_output --channel_creds_type=insecure '--metadata=authorization:Bearer <redacted>'`},
+		{ConnectCurl, `curl -H 'content-type: application/json' -H 'connect-protocol-version: 1' -A grpcexp/test -H 'authorization: Bearer <redacted>' -d '{"message":"it'"'"'s here"}' http://localhost:50051/echo.v1.EchoService/Echo`},
+	}
+	for _, tt := range tests {
+		got, err := tt.export(r)
+		if err != nil {
+			t.Fatalf("export returned error: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("export = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestCommandsUnixTLS(t *testing.T) {
+	r := Request{Method: "echo.v1.EchoService.Echo", Target: "/tmp/grpc.sock", Unix: true, Protoset: "echo.protoset"}
+
+	got, err := BufCurl(r)
+	if err != nil {
+		t.Fatalf("BufCurl returned error: %v", err)
+	}
+	want := `buf curl --protocol grpc --unix-socket /tmp/grpc.sock --schema 'echo.protoset#format=binpb' -d '{}' https://localhost/echo.v1.EchoService/Echo`
+	if got != want {
+		t.Errorf("BufCurl = %q, want %q", got, want)
+	}
+
+	got, err = GRPCCLI(r)
+	if err != nil {
+		t.Fatalf("GRPCCLI returned error: %v", err)
+	}
+	want = `grpc_cli call unix:/tmp/grpc.sock echo.v1.EchoService.Echo '{}' --json_input --json_output --channel_creds_type=ssl`
+	if got != want {
+		t.Errorf("GRPCCLI = %q, want %q", got, want)
+	}
+}
+
+func TestConnectCurlStreaming(t *testing.T) {
+	_, err := ConnectCurl(testRequest(echoMethod("EchoStream")))
+	if err == nil || !strings.Contains(err.Error(), "only supports unary methods") {
+		t.Fatalf("ConnectCurl error = %v, want only unary methods", err)
+	}
+}
+
+func TestGo(t *testing.T) {
+	got, err := Go(testRequest(echoMethod("Echo")))
+	if err != nil {
+		t.Fatalf("Go returned error: %v", err)
+	}
+	for _, want := range []string{
+		`echov1 "github.com/prnvbn/grpcexp/cmd/testserver/cmd/testserver/echo"`,
+		`grpc.NewClient("localhost:50051",`,
+		`grpc.WithTransportCredentials(insecure.NewCredentials()),`,
+		`"authorization", "Bearer <redacted>",`,
+		"req := &echov1.Message{}",
+		"protojson.Unmarshal([]byte(`{\"message\":\"it's here\"}`), req)",
+		"client := echov1.NewEchoServiceClient(conn)",
+		"resp, err := client.Echo(ctx, req)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Go snippet is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestGoStreams(t *testing.T) {
+	methods := hellov1.File_cmd_testserver_hello_hello_proto.Services().Get(0).Methods()
+	tests := []struct {
+		method protoreflect.Name
+		want   []string
+	}{
+		{"HelloStream", []string{"client.HelloStream(ctx)", "stream.Send(req)", "stream.CloseSend()", "stream.Recv()"}},
+		{"Firehose", []string{"client.Firehose(ctx, req)", "stream.Recv()"}},
+	}
+	for _, tt := range tests {
+		got, err := Go(testRequest(methods.ByName(tt.method)))
+		if err != nil {
+			t.Fatalf("Go returned error: %v", err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s snippet is missing %q:\n%s", tt.method, want, got)
+			}
+		}
+	}
+}
+
+func TestPython(t *testing.T) {
+	got, err := Python(testRequest(echoMethod("EchoStream")))
+	if err != nil {
+		t.Fatalf("Python returned error: %v", err)
+	}
+	want := `import grpc
+from google.protobuf import json_format
+
+from cmd.testserver.echo import echo_pb2, echo_pb2_grpc
+
+channel = grpc.insecure_channel("localhost:50051", options=[("grpc.primary_user_agent", "grpcexp/test")])
+stub = echo_pb2_grpc.EchoServiceStub(channel)
+metadata = [
+    ("authorization", "Bearer <redacted>"),
+]
+
+request = json_format.Parse("{\"message\":\"it's here\"}", echo_pb2.Message())
+for response in stub.EchoStream(iter([request]), metadata=metadata):
+    print(json_format.MessageToJson(response))
+`
+	if got != want {
+		t.Errorf("Python = %s, want %s", got, want)
+	}
+}
+
+func TestSnippetsNeedDescriptor(t *testing.T) {
+	r := Request{Method: "echo.v1.EchoService.Echo", Target: "localhost:50051"}
+	if _, err := Go(r); err == nil {
+		t.Error("Go returned no error without a descriptor")
+	}
+	if _, err := Python(r); err == nil {
+		t.Error("Python returned no error without a descriptor")
+	}
+}
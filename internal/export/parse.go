@@ -0,0 +1,280 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// grpcurlFlags are the grpcurl flags that take a value, and whether
+// ParseGRPCURL maps them to the request.
+var grpcurlFlags = map[string]bool{
+	"d":              true,
+	"H":              true,
+	"rpc-header":     true,
+	"protoset":       true,
+	"proto":          true,
+	"import-path":    true,
+	"authority":      true,
+	"user-agent":     true,
+	"max-msg-sz":     true,
+	"keepalive-time": true,
+	"format":         true,
+	"reflect-header": true,
+
+	"cacert":                      false,
+	"cert":                        false,
+	"key":                         false,
+	"servername":                  false,
+	"connect-timeout":             false,
+	"max-time":                    false,
+	"protoset-out":                false,
+	"proto-out-dir":               false,
+	"alts-handshaker-service":     false,
+	"alts-target-service-account": false,
+}
+
+// grpcurlBoolFlags are the grpcurl flags that take no value, and whether
+// ParseGRPCURL maps them to the request.
+var grpcurlBoolFlags = map[string]bool{
+	"plaintext": true,
+	"unix":      true,
+
+	"insecure":             false,
+	"use-reflection":       false,
+	"expand-headers":       false,
+	"emit-defaults":        false,
+	"allow-unknown-fields": false,
+	"format-error":         false,
+	"msg-template":         false,
+	"alts":                 false,
+	"v":                    false,
+	"vv":                   false,
+}
+
+// ParseGRPCURL reads a grpcurl command back into a request, the inverse of
+// GRPCURL. args may start with "grpcurl". Flags that have no equivalent in a
+// request are returned as ignored; unknown flags are an error.
+func ParseGRPCURL(args []string) (r Request, ignored []string, err error) {
+	if len(args) > 0 && args[0] == "grpcurl" {
+		args = args[1:]
+	}
+
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if mapped, ok := grpcurlBoolFlags[name]; ok {
+			set := true
+			if hasValue {
+				if set, err = strconv.ParseBool(value); err != nil {
+					return Request{}, nil, fmt.Errorf("invalid value %q for -%s", value, name)
+				}
+			}
+			if !mapped {
+				ignored = append(ignored, arg)
+				continue
+			}
+			switch name {
+			case "plaintext":
+				r.Plaintext = set
+			case "unix":
+				r.Unix = set
+			}
+			continue
+		}
+
+		mapped, ok := grpcurlFlags[name]
+		if !ok {
+			return Request{}, nil, fmt.Errorf("unknown grpcurl flag -%s", name)
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return Request{}, nil, fmt.Errorf("grpcurl flag -%s needs a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		if !mapped {
+			ignored = append(ignored, "-"+name+" "+value)
+			continue
+		}
+		if err := r.setGRPCURLFlag(name, value, &ignored); err != nil {
+			return Request{}, nil, err
+		}
+	}
+
+	switch {
+	case len(positional) > 0 && (positional[0] == "list" || positional[0] == "describe") ||
+		len(positional) > 1 && (positional[1] == "list" || positional[1] == "describe"):
+		return Request{}, nil, fmt.Errorf("grpcurl list and describe have nothing to open, only calls do")
+	case len(positional) != 2:
+		return Request{}, nil, fmt.Errorf("expected a target and a method, got %d arguments", len(positional))
+	}
+	r.Target = positional[0]
+	// grpcurl accepts both pkg.Service/Method and pkg.Service.Method
+	r.Method = strings.ReplaceAll(positional[1], "/", ".")
+	if r.Body == nil {
+		r.Body = map[string]any{}
+	}
+	return r, ignored, nil
+}
+
+func (r *Request) setGRPCURLFlag(name, value string, ignored *[]string) error {
+	switch name {
+	case "d":
+		body, more, err := parseData(value)
+		if err != nil {
+			return err
+		}
+		r.Body = body
+		if more {
+			*ignored = append(*ignored, "-d messages after the first")
+		}
+	case "H":
+		r.Headers = append(r.Headers, value)
+	case "rpc-header":
+		r.RPCHeaders = append(r.RPCHeaders, value)
+	case "reflect-header":
+		r.ReflectHeaders = append(r.ReflectHeaders, value)
+	case "protoset":
+		if r.Protoset != "" {
+			return fmt.Errorf("only one -protoset is supported")
+		}
+		r.Protoset = value
+	case "proto":
+		r.ProtoFiles = append(r.ProtoFiles, value)
+	case "import-path":
+		r.ImportPaths = append(r.ImportPaths, value)
+	case "authority":
+		r.Authority = value
+	case "user-agent":
+		r.UserAgent = value
+	case "max-msg-sz":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for -max-msg-sz", value)
+		}
+		r.MaxRecvMsgSize = n
+	case "keepalive-time":
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for -keepalive-time", value)
+		}
+		r.KeepaliveTime = time.Duration(seconds * float64(time.Second))
+	case "format":
+		if value != "json" {
+			return fmt.Errorf("only json request data is supported, not -format %s", value)
+		}
+	}
+	return nil
+}
+
+// parseData decodes the first message of a -d value and reports whether more
+// follow, as they do for client streams.
+func parseData(data string) (map[string]any, bool, error) {
+	if data == "@" {
+		return nil, false, fmt.Errorf("-d @ reads the request from stdin, paste it into -d instead")
+	}
+
+	dec := json.NewDecoder(strings.NewReader(data))
+	// keeps 64-bit integers exact
+	dec.UseNumber()
+	var body map[string]any
+	if err := dec.Decode(&body); err != nil {
+		if errors.Is(err, io.EOF) {
+			return map[string]any{}, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to parse -d: %w", err)
+	}
+	if body == nil {
+		body = map[string]any{}
+	}
+	_, err := dec.Token()
+	return body, err == nil, nil
+}
+
+// SplitCommand splits a command line the way a POSIX shell does, handling
+// quotes, backslash escapes and line continuations. Variables are not
+// expanded.
+func SplitCommand(line string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+	)
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\\':
+			if i+1 == len(runes) {
+				break
+			}
+			i++
+			if runes[i] != '\n' {
+				current.WriteRune(runes[i])
+				inArg = true
+			}
+		case c == '\'':
+			end := indexRune(runes, i+1, '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			current.WriteString(string(runes[i+1 : end]))
+			inArg = true
+			i = end
+		case c == '"':
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				// within double quotes backslashes only escape these
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+					i++
+					if runes[i] == '\n' {
+						continue
+					}
+				}
+				current.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inArg = true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+func indexRune(runes []rune, from int, r rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseGRPCURLRoundTrip(t *testing.T) {
+	want := Request{
+		Method:         "echo.v1.EchoService.Echo",
+		Body:           map[string]any{"message": "it's here", "count": json.Number("9007199254740993")},
+		Target:         "localhost:50051",
+		Plaintext:      true,
+		Authority:      "echo.internal",
+		UserAgent:      "grpcexp/test",
+		Headers:        []string{"authorization: Bearer <redacted>", "x-trace: 1"},
+		RPCHeaders:     []string{"x-rpc: 1"},
+		ReflectHeaders: []string{"x-reflect: 1"},
+		ProtoFiles:     []string{"echo.proto"},
+		ImportPaths:    []string{"proto", "third_party"},
+		MaxRecvMsgSize: 1 << 20,
+		KeepaliveTime:  1500 * time.Millisecond,
+	}
+	command, err := GRPCURL(want)
+	if err != nil {
+		t.Fatalf("GRPCURL returned error: %v", err)
+	}
+	args, err := SplitCommand(command)
+	if err != nil {
+		t.Fatalf("SplitCommand returned error: %v", err)
+	}
+
+	got, ignored, err := ParseGRPCURL(args)
+	if err != nil {
+		t.Fatalf("ParseGRPCURL returned error: %v", err)
+	}
+	if len(ignored) > 0 {
+		t.Errorf("ignored = %v, want none", ignored)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseGRPCURL(%s) =\n%+v\nwant\n%+v", command, got, want)
+	}
+}
+
+func TestParseGRPCURL(t *testing.T) {
+	args, err := SplitCommand(`grpcurl -insecure \
+  --unix=true -H "x-user: $USER" -max-time 5 \
+  -d '{"message": "hi"} {"message": "again"}' -msg-template /tmp/grpc.sock echo.v1.EchoService/EchoStream`)
+	if err != nil {
+		t.Fatalf("SplitCommand returned error: %v", err)
+	}
+
+	got, ignored, err := ParseGRPCURL(args)
+	if err != nil {
+		t.Fatalf("ParseGRPCURL returned error: %v", err)
+	}
+	want := Request{
+		Method:  "echo.v1.EchoService.EchoStream",
+		Body:    map[string]any{"message": "hi"},
+		Target:  "/tmp/grpc.sock",
+		Unix:    true,
+		Headers: []string{"x-user: $USER"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseGRPCURL = %+v, want %+v", got, want)
+	}
+	wantIgnored := []string{"-insecure", "-max-time 5", "-d messages after the first", "-msg-template"}
+	if !reflect.DeepEqual(ignored, wantIgnored) {
+		t.Errorf("ignored = %q, want %q", ignored, wantIgnored)
+	}
+}
+
+func TestParseGRPCURLErrors(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{`grpcurl -plaintext localhost:50051 list`, "list and describe"},
+		{`grpcurl -plaintext localhost:50051`, "expected a target and a method"},
+		{`grpcurl -frobnicate localhost:50051 echo.v1.EchoService.Echo`, "unknown grpcurl flag -frobnicate"},
+		{`grpcurl -d @ localhost:50051 echo.v1.EchoService.Echo`, "stdin"},
+		{`grpcurl -format text localhost:50051 echo.v1.EchoService.Echo`, "only json"},
+		{`grpcurl -d '{' localhost:50051 echo.v1.EchoService.Echo`, "failed to parse -d"},
+		{`grpcurl localhost:50051 echo.v1.EchoService.Echo -H`, "needs a value"},
+	}
+	for _, tt := range tests {
+		args, err := SplitCommand(tt.command)
+		if err != nil {
+			t.Fatalf("SplitCommand(%s) returned error: %v", tt.command, err)
+		}
+		_, _, err = ParseGRPCURL(args)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseGRPCURL(%s) error = %v, want %q", tt.command, err, tt.want)
+		}
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{`a  b	c`, []string{"a", "b", "c"}},
+		{`'a b' "c \"d\" \$e \x" f\ g`, []string{"a b", `c "d" $e \x`, "f g"}},
+		{"a \\\nb", []string{"a", "b"}},
+		{`a''b ""`, []string{"ab", ""}},
+	}
+	for _, tt := range tests {
+		got, err := SplitCommand(tt.line)
+		if err != nil {
+			t.Fatalf("SplitCommand(%q) returned error: %v", tt.line, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitCommand(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+
+	for _, line := range []string{`'a`, `"a`} {
+		if _, err := SplitCommand(line); err == nil {
+			t.Errorf("SplitCommand(%q) returned no error", line)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// snippet is what the code templates are filled with.
+type snippet struct {
+	Request
+	BodyLiteral string
+	// Headers are split into names and values
+	HeaderPairs     [][2]string
+	ClientStreaming bool
+	ServerStreaming bool
+
+	Service string
+	Method  string
+	// Input is the request message type as the generated code names it
+	Input string
+	// ServicePkg and InputPkg are the generated packages or modules holding
+	// the service and the request message
+	ServicePkg string
+	InputPkg   string
+	Imports    []string
+
+	CoreTarget string
+	// ChannelOptions are the Python channel's options argument, if any
+	ChannelOptions string
+}
+
+func newSnippet(r Request, quote func(string) string) (snippet, error) {
+	if r.Desc == nil {
+		return snippet{}, fmt.Errorf("no descriptor for %s", r.Method)
+	}
+	body, err := r.body()
+	if err != nil {
+		return snippet{}, err
+	}
+	s := snippet{
+		Request:     r,
+		BodyLiteral: quote(body),
+		CoreTarget:  r.coreTarget(),
+		Service:     string(r.Desc.Parent().Name()),
+		Method:      string(r.Desc.Name()),
+	}
+	s.ClientStreaming, s.ServerStreaming = r.streaming()
+	for _, h := range r.callHeaders() {
+		name, value := header(h)
+		s.HeaderPairs = append(s.HeaderPairs, [2]string{name, value})
+	}
+	return s, nil
+}
+
+// relativeName is the name of a message within its package, e.g. Outer.Inner.
+func relativeName(msg protoreflect.MessageDescriptor) string {
+	pkg := string(msg.ParentFile().Package())
+	name := string(msg.FullName())
+	if pkg == "" {
+		return name
+	}
+	return strings.TrimPrefix(name, pkg+".")
+}
+
+var goTemplate = template.Must(template.New("go").Parse(`package main
+
+import (
+{{- range .Imports}}
+{{if .}}	{{.}}{{end}}
+{{- end}}
+)
+
+func main() {
+	conn, err := grpc.NewClient({{printf "%q" .CoreTarget}},
+{{- if .Plaintext}}
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+{{- else}}
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})),
+{{- end}}
+{{- if .Authority}}
+		grpc.WithAuthority({{printf "%q" .Authority}}),
+{{- end}}
+{{- if .UserAgent}}
+		grpc.WithUserAgent({{printf "%q" .UserAgent}}),
+{{- end}}
+{{- if .MaxRecvMsgSize}}
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize({{.MaxRecvMsgSize}})),
+{{- end}}
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+{{- if .HeaderPairs}}
+	ctx = metadata.AppendToOutgoingContext(ctx,
+{{- range .HeaderPairs}}
+		{{printf "%q" (index . 0)}}, {{printf "%q" (index . 1)}},
+{{- end}}
+	)
+{{- end}}
+
+	req := &{{.InputPkg}}.{{.Input}}{}
+	if err := protojson.Unmarshal([]byte({{.BodyLiteral}}), req); err != nil {
+		log.Fatal(err)
+	}
+
+	client := {{.ServicePkg}}.New{{.Service}}Client(conn)
+{{- if .ClientStreaming}}
+	stream, err := client.{{.Method}}(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := stream.Send(req); err != nil {
+		log.Fatal(err)
+	}
+{{- if .ServerStreaming}}
+	if err := stream.CloseSend(); err != nil {
+		log.Fatal(err)
+	}
+{{- else}}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(protojson.Format(resp))
+{{- end}}
+{{- else if .ServerStreaming}}
+	stream, err := client.{{.Method}}(ctx, req)
+	if err != nil {
+		log.Fatal(err)
+	}
+{{- else}}
+	resp, err := client.{{.Method}}(ctx, req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(protojson.Format(resp))
+{{- end}}
+{{- if .ServerStreaming}}
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(protojson.Format(resp))
+	}
+{{- end}}
+}
+`))
+
+// Go writes a program that makes the call with the client generated by
+// protoc-gen-go-grpc.
+func Go(r Request) (string, error) {
+	s, err := newSnippet(r, goQuote)
+	if err != nil {
+		return "", err
+	}
+	s.Input = strings.ReplaceAll(relativeName(r.Desc.Input()), ".", "_")
+
+	// the standard library and the other imports are grouped separately
+	std := []string{`"context"`, `"fmt"`, `"log"`}
+	imports := []string{`"google.golang.org/grpc"`, `"google.golang.org/protobuf/encoding/protojson"`}
+	if r.Plaintext {
+		imports = append(imports, `"google.golang.org/grpc/credentials/insecure"`)
+	} else {
+		std = append(std, `"crypto/tls"`)
+		imports = append(imports, `"google.golang.org/grpc/credentials"`)
+	}
+	if len(s.HeaderPairs) > 0 {
+		imports = append(imports, `"google.golang.org/grpc/metadata"`)
+	}
+	if s.ServerStreaming {
+		std = append(std, `"errors"`, `"io"`)
+	}
+	s.ServicePkg, imports = goImport(r.Desc.ParentFile(), imports)
+	s.InputPkg, imports = goImport(r.Desc.Input().ParentFile(), imports)
+	s.Imports = append(append(std, ""), imports...)
+
+	var buf bytes.Buffer
+	if err := goTemplate.Execute(&buf, s); err != nil {
+		return "", err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("failed to format Go snippet: %w", err)
+	}
+	return string(src), nil
+}
+
+// goImport returns the name of the package generated from file and adds its
+// import to imports if it isn't there yet.
+func goImport(file protoreflect.FileDescriptor, imports []string) (string, []string) {
+	var goPackage string
+	if opts, ok := file.Options().(*descriptorpb.FileOptions); ok {
+		goPackage = opts.GetGoPackage()
+	}
+
+	importPath, name, ok := strings.Cut(goPackage, ";")
+	if !ok {
+		name = goIdentifier(path.Base(importPath))
+	}
+	spec := fmt.Sprintf("%s %q", name, importPath)
+	if goPackage == "" {
+		// without go_package the import path is chosen when generating
+		name = goIdentifier(strings.ReplaceAll(string(file.Package()), ".", ""))
+		spec = fmt.Sprintf("%s %q // code generated from %s", name, path.Dir(file.Path()), file.Path())
+	}
+
+	for _, imp := range imports {
+		if imp == spec {
+			return name, imports
+		}
+	}
+	return name, append(imports, spec)
+}
+
+func goIdentifier(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// goQuote writes s as a raw string literal when it can.
+func goQuote(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+var pythonTemplate = template.Must(template.New("python").Parse(`import grpc
+from google.protobuf import json_format
+{{range .Imports}}
+{{.}}
+{{- end}}
+
+{{if .Plaintext -}}
+channel = grpc.insecure_channel({{printf "%q" .CoreTarget}}{{.ChannelOptions}})
+{{- else -}}
+channel = grpc.secure_channel({{printf "%q" .CoreTarget}}, grpc.ssl_channel_credentials(){{.ChannelOptions}})
+{{- end}}
+stub = {{.ServicePkg}}_grpc.{{.Service}}Stub(channel)
+{{- if .HeaderPairs}}
+metadata = [
+{{- range .HeaderPairs}}
+    ({{printf "%q" (index . 0)}}, {{printf "%q" (index . 1)}}),
+{{- end}}
+]
+{{- end}}
+
+request = json_format.Parse({{.BodyLiteral}}, {{.InputPkg}}.{{.Input}}())
+{{- $args := "request"}}{{if .ClientStreaming}}{{$args = "iter([request])"}}{{end}}
+{{- if .HeaderPairs}}{{$args = printf "%s, metadata=metadata" $args}}{{end}}
+{{- if .ServerStreaming}}
+for response in stub.{{.Method}}({{$args}}):
+    print(json_format.MessageToJson(response))
+{{- else}}
+response = stub.{{.Method}}({{$args}})
+print(json_format.MessageToJson(response))
+{{- end}}
+`))
+
+// Python writes a script that makes the call with the stub generated by
+// grpcio-tools.
+func Python(r Request) (string, error) {
+	s, err := newSnippet(r, strconv.Quote)
+	if err != nil {
+		return "", err
+	}
+	s.Input = relativeName(r.Desc.Input())
+
+	serviceModule, serviceFrom := pythonModule(r.Desc.ParentFile())
+	inputModule, inputFrom := pythonModule(r.Desc.Input().ParentFile())
+	s.ServicePkg, s.InputPkg = serviceModule, inputModule
+	s.Imports = []string{pythonImport(serviceFrom, serviceModule, serviceModule+"_grpc")}
+	if inputModule != serviceModule || inputFrom != serviceFrom {
+		s.Imports = append(s.Imports, pythonImport(inputFrom, inputModule))
+	}
+
+	var options []string
+	if r.Authority != "" {
+		options = append(options, fmt.Sprintf("(%q, %q)", "grpc.default_authority", r.Authority))
+	}
+	if r.UserAgent != "" {
+		options = append(options, fmt.Sprintf("(%q, %q)", "grpc.primary_user_agent", r.UserAgent))
+	}
+	if r.MaxRecvMsgSize > 0 {
+		options = append(options, fmt.Sprintf("(%q, %d)", "grpc.max_receive_message_length", r.MaxRecvMsgSize))
+	}
+	if len(options) > 0 {
+		s.ChannelOptions = ", options=[" + strings.Join(options, ", ") + "]"
+	}
+
+	var buf bytes.Buffer
+	if err := pythonTemplate.Execute(&buf, s); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// pythonModule returns the name of the _pb2 module generated from file and
+// the package it is imported from, e.g. echo_pb2 from echo.v1.
+func pythonModule(file protoreflect.FileDescriptor) (module, from string) {
+	dir, name := path.Split(strings.TrimSuffix(file.Path(), ".proto"))
+	module = strings.ReplaceAll(name, "-", "_") + "_pb2"
+	from = strings.ReplaceAll(strings.Trim(dir, "/"), "/", ".")
+	return module, from
+}
+
+func pythonImport(from string, modules ...string) string {
+	if from == "" {
+		return "import " + strings.Join(modules, ", ")
+	}
+	return "from " + from + " import " + strings.Join(modules, ", ")
+}
@@ -0,0 +1,225 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// tokenRefreshSkew is how long before expiry a cached token is considered stale.
+const tokenRefreshSkew = 30 * time.Second
+
+// defaultExecTokenTTL is used when an exec plugin prints a bare token without an expiry.
+const defaultExecTokenTTL = 5 * time.Minute
+
+// TokenProvider supplies bearer tokens for outgoing RPCs.
+type TokenProvider interface {
+	credentials.PerRPCCredentials
+	// Token returns a valid token, fetching a new one if the cached token has expired.
+	Token(ctx context.Context) (string, error)
+}
+
+var (
+	_ TokenProvider = &StaticToken{}
+	_ TokenProvider = &ExecToken{}
+	_ TokenProvider = &OAuth2ClientCredentials{}
+)
+
+// StaticToken always returns the same token.
+type StaticToken struct {
+	Value string
+}
+
+func (t *StaticToken) Token(_ context.Context) (string, error) {
+	return t.Value, nil
+}
+
+func (t *StaticToken) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	return bearerMetadata(ctx, t)
+}
+
+func (t *StaticToken) RequireTransportSecurity() bool { return false }
+
+// ExecToken runs a command and uses its stdout as the token. The command may
+// print a bare token or a JSON object of the form
+// {"token": "...", "expiry": "<RFC 3339>"} (access_token and expires_in are
+// accepted too). Tokens are cached until they expire.
+type ExecToken struct {
+	Command []string
+
+	cache tokenCache
+}
+
+func (t *ExecToken) Token(ctx context.Context) (string, error) {
+	return t.cache.get(func() (string, time.Time, error) {
+		return t.fetch(ctx)
+	})
+}
+
+func (t *ExecToken) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	return bearerMetadata(ctx, t)
+}
+
+func (t *ExecToken) RequireTransportSecurity() bool { return false }
+
+func (t *ExecToken) fetch(ctx context.Context) (string, time.Time, error) {
+	if len(t.Command) == 0 {
+		return "", time.Time{}, fmt.Errorf("token exec command is empty")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.Command[0], t.Command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", time.Time{}, fmt.Errorf("token command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	out := bytes.TrimSpace(stdout.Bytes())
+	if len(out) == 0 {
+		return "", time.Time{}, fmt.Errorf("token command printed nothing")
+	}
+	if out[0] != '{' {
+		return string(out), time.Now().Add(defaultExecTokenTTL), nil
+	}
+
+	var resp struct {
+		Token       string    `json:"token"`
+		AccessToken string    `json:"access_token"`
+		Expiry      time.Time `json:"expiry"`
+		ExpiresIn   int64     `json:"expires_in"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to parse token command output: %w", err)
+	}
+
+	token := resp.Token
+	if token == "" {
+		token = resp.AccessToken
+	}
+	if token == "" {
+		return "", time.Time{}, fmt.Errorf("token command output has no token")
+	}
+
+	expiry := resp.Expiry
+	switch {
+	case !expiry.IsZero():
+	case resp.ExpiresIn > 0:
+		expiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	default:
+		expiry = time.Now().Add(defaultExecTokenTTL)
+	}
+	return token, expiry, nil
+}
+
+// OAuth2ClientCredentials fetches tokens using the OAuth2 client credentials grant.
+type OAuth2ClientCredentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	HTTPClient   *http.Client
+
+	cache tokenCache
+}
+
+func (t *OAuth2ClientCredentials) Token(ctx context.Context) (string, error) {
+	return t.cache.get(func() (string, time.Time, error) {
+		return t.fetch(ctx)
+	})
+}
+
+func (t *OAuth2ClientCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	return bearerMetadata(ctx, t)
+}
+
+func (t *OAuth2ClientCredentials) RequireTransportSecurity() bool { return false }
+
+func (t *OAuth2ClientCredentials) fetch(ctx context.Context) (string, time.Time, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(t.Scopes) > 0 {
+		form.Set("scope", strings.Join(t.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(t.ClientID), url.QueryEscape(t.ClientSecret))
+
+	httpClient := t.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // nothing useful to do with a close error here
+
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("token request failed: %s", resp.Status)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if body.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("token response has no access_token")
+	}
+
+	// tokens without expires_in are treated as long lived
+	var expiry time.Time
+	if body.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return body.AccessToken, expiry, nil
+}
+
+type tokenCache struct {
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// get returns the cached token or calls fetch when it is missing or about to expire.
+// A zero expiry means the token never expires.
+func (c *tokenCache) get(fetch func() (string, time.Time, error)) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && (c.expiry.IsZero() || time.Now().Add(tokenRefreshSkew).Before(c.expiry)) {
+		return c.token, nil
+	}
+
+	token, expiry, err := fetch()
+	if err != nil {
+		return "", err
+	}
+	c.token = token
+	c.expiry = expiry
+	return token, nil
+}
+
+func bearerMetadata(ctx context.Context, p TokenProvider) (map[string]string, error) {
+	token, err := p.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prnvbn/grpcexp/cmd/testserver/server"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestOAuth2ClientCredentialsCachesToken(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm: %v", err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("grant_type = %q, want client_credentials", got)
+		}
+		if id, secret, _ := r.BasicAuth(); id != "id" || secret != "secret" {
+			t.Errorf("basic auth = %q:%q, want id:secret", id, secret)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":3600}`, requests)
+	}))
+	defer srv.Close()
+
+	provider := &OAuth2ClientCredentials{
+		TokenURL:     srv.URL,
+		ClientID:     "id",
+		ClientSecret: "secret",
+	}
+
+	for range 2 {
+		md, err := provider.GetRequestMetadata(context.Background())
+		if err != nil {
+			t.Fatalf("GetRequestMetadata returned error: %v", err)
+		}
+		if got, want := md["authorization"], "Bearer token-1"; got != want {
+			t.Fatalf("authorization = %q, want %q", got, want)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("token endpoint called %d times, want 1", requests)
+	}
+}
+
+func TestExecTokenParsesJSON(t *testing.T) {
+	provider := &ExecToken{
+		Command: []string{"echo", `{"token":"abc","expiry":"2999-01-01T00:00:00Z"}`},
+	}
+
+	got, err := provider.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if got != "abc" {
+		t.Fatalf("Token = %q, want abc", got)
+	}
+}
+
+func TestTokenProviderAuthenticatesReflection(t *testing.T) {
+	requireToken := func(ctx context.Context) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer secret" {
+			return status.Errorf(codes.Unauthenticated, "authorization = %q", got)
+		}
+		return nil
+	}
+	dialer := server.Start(t,
+		grpclib.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+			if err := requireToken(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpclib.ChainStreamInterceptor(func(srv any, ss grpclib.ServerStream, _ *grpclib.StreamServerInfo, handler grpclib.StreamHandler) error {
+			if err := requireToken(ss.Context()); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := NewClient(ctx, Config{
+		Target: "bufnet",
+		Dialer: dialer,
+		Creds:  insecure.NewCredentials(),
+		Auth:   &StaticToken{Value: "secret"},
+	})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	defer client.Close() //nolint:errcheck // test client
+
+	services, err := client.ListServices()
+	if err != nil {
+		t.Fatalf("ListServices returned error: %v", err)
+	}
+	if !slices.Contains(services, "helloworld.Greeter") {
+		t.Fatalf("ListServices = %v, want helloworld.Greeter", services)
+	}
+
+	reply, err := client.InvokeRPC(ctx, "helloworld.Greeter.SayHello", map[string]any{"name": "auth"})
+	if err != nil {
+		t.Fatalf("InvokeRPC returned error: %v", err)
+	}
+	if !strings.Contains(reply, "Hello auth") {
+		t.Fatalf("InvokeRPC = %s, want Hello auth", reply)
+	}
+}
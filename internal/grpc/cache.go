@@ -0,0 +1,173 @@
+package grpc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/fullstorydev/grpcurl"
+	"github.com/jhump/protoreflect/desc" //nolint:staticcheck // Deprecated package but required by grpcurl
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DefaultCacheDir returns the directory reflection results are cached in by default.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "grpcexp", "descriptors"), nil
+}
+
+// WriteProtoset writes every file known to the descriptor source to w as a
+// serialized FileDescriptorSet.
+func (c *Client) WriteProtoset(w io.Writer) error {
+	data, err := marshalFileDescriptorSet(c.descriptorSource())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// refreshDescriptors re-fetches every file over reflection, swaps the result in
+// as the descriptor source and, if the file set changed, updates the on-disk
+// cache and tells DescriptorsChanged.
+func (c *Client) refreshDescriptors(cc *grpc.ClientConn) error {
+	source, refClient := reflectionSource(cc, c.reflectionHeaders())
+	data, err := marshalFileDescriptorSet(source)
+	if err != nil {
+		refClient.Reset()
+		return fmt.Errorf("failed to refresh reflection: %w", err)
+	}
+
+	if c.config.CacheDir != "" {
+		if err := c.writeDescriptorCache(data); err != nil {
+			refClient.Reset()
+			return err
+		}
+	}
+
+	hash := contentHash(data)
+	c.mu.Lock()
+	old := c.refClient
+	c.source = source
+	c.refClient = refClient
+	if hash != c.descriptorsHash {
+		c.descriptorsHash = hash
+		close(c.descriptorsChanged)
+		c.descriptorsChanged = make(chan struct{})
+	}
+	c.mu.Unlock()
+
+	if old != nil {
+		old.Reset()
+	}
+	return nil
+}
+
+// cachedDescriptorSource loads the cached descriptors for the target, if any,
+// along with their content hash.
+func (c *Client) cachedDescriptorSource() (grpcurl.DescriptorSource, string, error) {
+	data, err := os.ReadFile(c.descriptorCachePath())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to read descriptor cache: %w", err)
+	}
+
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &fds); err != nil {
+		return nil, "", fmt.Errorf("failed to parse descriptor cache: %w", err)
+	}
+	source, err := grpcurl.DescriptorSourceFromFileDescriptorSet(&fds)
+	if err != nil {
+		return nil, "", err
+	}
+	return source, contentHash(data), nil
+}
+
+// writeDescriptorCache stores data unless the cached file set already has the same content hash.
+func (c *Client) writeDescriptorCache(data []byte) error {
+	path := c.descriptorCachePath()
+	if existing, err := os.ReadFile(path); err == nil && contentHash(existing) == contentHash(data) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create descriptor cache: %w", err)
+	}
+
+	// write to a temporary file first so readers never see a partial cache
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write descriptor cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write descriptor cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write descriptor cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write descriptor cache: %w", err)
+	}
+	return nil
+}
+
+// descriptorCachePath is where the descriptors of the target are cached. The
+// file is found by target alone: its content hash can only be known after
+// reflecting, which the cache is there to avoid at startup. The hash instead
+// decides whether a refresh rewrites the file and tells DescriptorsChanged.
+func (c *Client) descriptorCachePath() string {
+	return filepath.Join(c.config.CacheDir, contentHash([]byte(c.Target()))[:16]+".protoset")
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// marshalFileDescriptorSet deterministically serializes every file in the
+// source, dependencies first, so equal file sets produce equal bytes.
+func marshalFileDescriptorSet(source grpcurl.DescriptorSource) ([]byte, error) {
+	files, err := grpcurl.GetAllFiles(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var fds descriptorpb.FileDescriptorSet
+	seen := make(map[string]bool)
+	var add func(fd *desc.FileDescriptor)
+	add = func(fd *desc.FileDescriptor) {
+		if seen[fd.GetName()] {
+			return
+		}
+		seen[fd.GetName()] = true
+		for _, dep := range fd.GetDependencies() {
+			add(dep)
+		}
+		fds.File = append(fds.File, fd.AsFileDescriptorProto())
+	}
+	for _, fd := range files {
+		add(fd)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(&fds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal descriptors: %w", err)
+	}
+	return data, nil
+}
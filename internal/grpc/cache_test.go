@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"os"
+	"testing"
+
+	"github.com/fullstorydev/grpcurl"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestDescriptorCacheRoundTrip(t *testing.T) {
+	source, err := grpcurl.DescriptorSourceFromFileDescriptorSet(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(durationpb.File_google_protobuf_duration_proto),
+		},
+	})
+	if err != nil {
+		t.Fatalf("DescriptorSourceFromFileDescriptorSet returned error: %v", err)
+	}
+
+	data, err := marshalFileDescriptorSet(source)
+	if err != nil {
+		t.Fatalf("marshalFileDescriptorSet returned error: %v", err)
+	}
+
+	client := &Client{config: Config{Target: "localhost:50051", CacheDir: t.TempDir()}}
+	if err := client.writeDescriptorCache(data); err != nil {
+		t.Fatalf("writeDescriptorCache returned error: %v", err)
+	}
+
+	info, err := os.Stat(client.descriptorCachePath())
+	if err != nil {
+		t.Fatalf("cache file missing: %v", err)
+	}
+
+	// an unchanged file set must not rewrite the cache
+	if err := client.writeDescriptorCache(data); err != nil {
+		t.Fatalf("writeDescriptorCache returned error: %v", err)
+	}
+	again, err := os.Stat(client.descriptorCachePath())
+	if err != nil {
+		t.Fatalf("cache file missing: %v", err)
+	}
+	if !again.ModTime().Equal(info.ModTime()) {
+		t.Fatalf("cache rewritten for unchanged file set")
+	}
+
+	cached, hash, err := client.cachedDescriptorSource()
+	if err != nil {
+		t.Fatalf("cachedDescriptorSource returned error: %v", err)
+	}
+	if hash != contentHash(data) {
+		t.Fatalf("cached hash = %s, want %s", hash, contentHash(data))
+	}
+	if _, err := cached.FindSymbol("google.protobuf.Duration"); err != nil {
+		t.Fatalf("FindSymbol on cached source returned error: %v", err)
+	}
+}
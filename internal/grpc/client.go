@@ -6,66 +6,225 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"sort"
-	"strings"
-	"unicode"
+	"sync"
+	"time"
 
 	"github.com/fullstorydev/grpcurl"
 	oldproto "github.com/golang/protobuf/proto" //nolint:staticcheck // grpcurl uses the legacy proto API
 	"github.com/jhump/protoreflect/desc"        //nolint:staticcheck // Deprecated package but required by grpcurl
 	"github.com/jhump/protoreflect/grpcreflect"
+	"github.com/prnvbn/grpcexp/internal/export"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type Config struct {
-	Target    string
+	Target string
+	// Network is either "tcp" (the default) or "unix", in which case Target is a socket path.
+	Network string
+	// Dialer, if set, replaces the default dialer used to reach Target.
+	Dialer    func(ctx context.Context, addr string) (net.Conn, error)
 	Creds     credentials.TransportCredentials
 	UserAgent string
 	Protoset  string
+	// ProtoFiles are parsed for descriptors instead of using reflection, with
+	// imports resolved against ImportPaths.
+	ProtoFiles  []string
+	ImportPaths []string
+	// Auth, if set, provides a bearer token that is attached to every RPC,
+	// reflection included.
+	Auth TokenProvider
+	// Headers are sent with every RPC as "name: value", reflection included.
+	Headers []string
+	// RPCHeaders are only sent with calls and ReflectHeaders only with
+	// reflection.
+	RPCHeaders     []string
+	ReflectHeaders []string
+	// MaxRecvMsgSize and MaxSendMsgSize override the default message size limits when non-zero.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+	// KeepaliveTime enables client keepalive pings after this much inactivity when non-zero.
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// Authority overrides the :authority pseudo-header.
+	Authority string
+	// Gzip compresses requests with gzip.
+	Gzip bool
+	// InitialWindowSize and InitialConnWindowSize set the HTTP/2 flow control windows when non-zero.
+	InitialWindowSize     int32
+	InitialConnWindowSize int32
+	// RevealTokens includes the real token instead of a placeholder in generated grpcurl commands.
+	RevealTokens bool
+	// Offline skips dialing at startup. It requires a protoset or cached
+	// descriptors; the client connects lazily on the first call instead.
+	Offline bool
+	// CacheDir, if set, caches reflected descriptors on disk so later sessions
+	// start instantly while reflection is refreshed in the background.
+	CacheDir string
 }
 
+// redactedToken stands in for bearer tokens in generated commands.
+const redactedToken = "<redacted>"
+
 type Client struct {
-	source grpcurl.DescriptorSource
-	conn   *grpc.ClientConn
+	mu        sync.RWMutex
+	source    grpcurl.DescriptorSource
+	refClient *grpcreflect.Client
+	conn      *grpc.ClientConn
+	dialErr   error
+
+	// descriptorsHash is the content hash of the descriptor set last loaded
+	// from the cache or reflection
+	descriptorsHash string
+	// descriptorsChanged is closed when a refresh changes the descriptor set
+	descriptorsChanged chan struct{}
+
+	// dialMu serialises lazy connection attempts
+	dialMu    sync.Mutex
+	connected chan struct{}
+
 	config Config
 }
 
+// NewClient dials the target and loads descriptors. When descriptors are
+// available locally (from a protoset or the cache) and the server is
+// unreachable, or Offline is set, the client starts disconnected and connects
+// on the first call.
 func NewClient(ctx context.Context, config Config) (*Client, error) {
-	opts := []grpc.DialOption{
-		grpc.WithUserAgent(config.UserAgent),
-	}
-	cc, err := grpcurl.BlockingDial(ctx, "", config.Target, config.Creds, opts...)
-	if err != nil {
-		return nil, err
+	client := &Client{
+		config:             config,
+		connected:          make(chan struct{}),
+		descriptorsChanged: make(chan struct{}),
 	}
-
-	var source grpcurl.DescriptorSource
-	if config.Protoset != "" {
-		source, err = grpcurl.DescriptorSourceFromProtoSets(config.Protoset)
+	switch {
+	case config.Protoset != "":
+		var err error
+		client.source, err = grpcurl.DescriptorSourceFromProtoSets(config.Protoset)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load protoset file: %w", err)
 		}
-	} else {
-		refCtx := context.Background()
-		refClient := grpcreflect.NewClientAuto(refCtx, cc)
-		refClient.AllowMissingFileDescriptors()
-		source = grpcurl.DescriptorSourceFromServer(refCtx, refClient)
+	case len(config.ProtoFiles) > 0:
+		var err error
+		client.source, err = grpcurl.DescriptorSourceFromProtoFiles(config.ImportPaths, config.ProtoFiles...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proto files: %w", err)
+		}
+	case config.CacheDir != "":
+		// a broken cache is not fatal, reflection will replace it
+		client.source, client.descriptorsHash, _ = client.cachedDescriptorSource()
 	}
 
-	return &Client{source: source, conn: cc, config: config}, nil
+	if config.Offline {
+		if client.source == nil {
+			return nil, fmt.Errorf("offline mode requires a protoset, proto files or cached descriptors")
+		}
+		return client, nil
+	}
+
+	cc, err := client.dial(ctx)
+	if err != nil {
+		if client.source == nil {
+			return nil, err
+		}
+		client.dialErr = err
+		return client, nil
+	}
+	client.setConn(cc)
+
+	return client, nil
+}
+
+func (c *Client) dial(ctx context.Context) (*grpc.ClientConn, error) {
+	return grpcurl.BlockingDial(ctx, c.config.Network, c.config.Target, c.config.Creds, dialOptions(c.config)...)
+}
+
+// setConn installs a freshly dialed connection, setting up reflection if no
+// local descriptors are used and refreshing the descriptor cache in the background.
+func (c *Client) setConn(cc *grpc.ClientConn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn = cc
+	c.dialErr = nil
+	close(c.connected)
+
+	if c.localDescriptors() {
+		return
+	}
+	if c.source == nil {
+		c.source, c.refClient = reflectionSource(cc, c.reflectionHeaders())
+	}
+	if c.config.CacheDir != "" {
+		go func() {
+			// cached or lazily reflected descriptors keep working if this fails
+			_ = c.refreshDescriptors(cc)
+		}()
+	}
+}
+
+func reflectionSource(cc *grpc.ClientConn, headers []string) (grpcurl.DescriptorSource, *grpcreflect.Client) {
+	refCtx := context.Background()
+	if len(headers) > 0 {
+		refCtx = metadata.NewOutgoingContext(refCtx, grpcurl.MetadataFromHeaders(headers))
+	}
+	refClient := grpcreflect.NewClientAuto(refCtx, cc)
+	refClient.AllowMissingFileDescriptors()
+	return grpcurl.DescriptorSourceFromServer(refCtx, refClient), refClient
+}
+
+// localDescriptors reports whether descriptors come from a protoset or proto
+// files rather than server reflection.
+func (c *Client) localDescriptors() bool {
+	return c.config.Protoset != "" || len(c.config.ProtoFiles) > 0
+}
+
+// DescriptorsChanged returns a channel that is closed the next time
+// descriptors re-fetched over reflection differ from the ones in use, such as
+// when the background refresh finds services the cache didn't have.
+func (c *Client) DescriptorsChanged() <-chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.descriptorsChanged
 }
 
+// descriptorSource returns the current descriptor source, which is replaced when reflection is refreshed.
+func (c *Client) descriptorSource() grpcurl.DescriptorSource {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.source
+}
+
+// StatusError is returned for calls that end with a status other than OK.
+// status.FromError recovers the status from it.
+type StatusError struct {
+	Status *status.Status
+}
+
+func (e *StatusError) Error() string {
+	return "RPC error: " + e.Status.Message()
+}
+
+func (e *StatusError) GRPCStatus() *status.Status {
+	return e.Status
+}
+
+// InvokeRPC calls a unary method with request as JSON and returns the response
+// as JSON. Outgoing metadata in ctx is sent with the call.
 func (c *Client) InvokeRPC(ctx context.Context, methodFullName string, request map[string]any) (string, error) {
 	jsonData, err := json.Marshal(request)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	source := c.descriptorSource()
 	requestData := bytes.NewReader(jsonData)
-	rf, formatter, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, c.source, requestData, grpcurl.FormatOptions{})
+	rf, formatter, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, source, requestData, grpcurl.FormatOptions{})
 	if err != nil {
 		return "", fmt.Errorf("failed to create request parser: %w", err)
 	}
@@ -78,24 +237,37 @@ func (c *Client) InvokeRPC(ctx context.Context, methodFullName string, request m
 		VerbosityLevel: 0,
 	}
 
-	err = grpcurl.InvokeRPC(ctx, c.source, c.conn, methodFullName, nil, handler, rf.Next)
+	headers := c.requestHeaders(ctx)
+
+	cc, err := c.clientConnOrDial(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	err = grpcurl.InvokeRPC(ctx, source, cc, methodFullName, headers, handler, rf.Next)
 	if err != nil {
 		return "", fmt.Errorf("RPC invocation failed: %w", err)
 	}
 
-	if handler.Status.Code() != 0 {
-		return "", fmt.Errorf("RPC error: %s", handler.Status.Message())
+	if handler.Status.Code() != codes.OK {
+		return "", &StatusError{Status: handler.Status}
 	}
 
 	return responseBuf.String(), nil
 }
 
+// InvokeStreaming calls a method of any kind, sending requests until the
+// channel is closed and reporting what happens on events. Outgoing metadata in
+// ctx is sent with the call.
 func (c *Client) InvokeStreaming(ctx context.Context, methodFullName string, requests <-chan map[string]any, events chan<- StreamEvent) error {
-	_, formatter, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, c.source, nil, grpcurl.FormatOptions{})
+	source := c.descriptorSource()
+	_, formatter, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, source, bytes.NewReader(nil), grpcurl.FormatOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to create response formatter: %w", err)
 	}
 
+	headers := c.requestHeaders(ctx)
+
 	handler := &streamEventHandler{
 		formatter: formatter,
 		events:    events,
@@ -112,7 +284,7 @@ func (c *Client) InvokeStreaming(ctx context.Context, methodFullName string, req
 			return fmt.Errorf("failed to marshal request: %w", err)
 		}
 
-		rf, _, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, c.source, bytes.NewReader(jsonData), grpcurl.FormatOptions{})
+		rf, _, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, source, bytes.NewReader(jsonData), grpcurl.FormatOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to create request parser: %w", err)
 		}
@@ -120,13 +292,19 @@ func (c *Client) InvokeStreaming(ctx context.Context, methodFullName string, req
 		return rf.Next(msg)
 	}
 
-	if err := grpcurl.InvokeRPC(ctx, c.source, c.conn, methodFullName, nil, handler, requestSupplier); err != nil {
+	cc, err := c.clientConnOrDial(ctx)
+	if err != nil {
+		events <- StreamEvent{Kind: StreamEventError, Err: err}
+		return err
+	}
+
+	if err := grpcurl.InvokeRPC(ctx, source, cc, methodFullName, headers, handler, requestSupplier); err != nil {
 		events <- StreamEvent{Kind: StreamEventError, Err: fmt.Errorf("RPC invocation failed: %w", err)}
 		return err
 	}
 
 	if handler.status != nil && handler.status.Code() != codes.OK {
-		err := fmt.Errorf("RPC error: %s", handler.status.Message())
+		err := &StatusError{Status: handler.status}
 		events <- StreamEvent{Kind: StreamEventError, Err: err}
 		return err
 	}
@@ -137,40 +315,80 @@ func (c *Client) InvokeStreaming(ctx context.Context, methodFullName string, req
 
 // GRPCURLCommand returns a shell-safe grpcurl command for the current client session.
 func (c *Client) GRPCURLCommand(methodFullName string, request map[string]any) (string, error) {
-	jsonData, err := json.Marshal(request)
+	r, err := c.exportRequest(methodFullName, request)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", err
 	}
+	return export.GRPCURL(r)
+}
 
-	args := []string{"grpcurl"}
-	if c.config.Creds.Info().SecurityProtocol == "insecure" {
-		args = append(args, "-plaintext")
-	}
-	if c.config.Protoset != "" {
-		args = append(args, "-protoset", c.config.Protoset)
+// ExportRequest describes a call of method with request on the current
+// client session for the export formats.
+func (c *Client) ExportRequest(method protoreflect.MethodDescriptor, request map[string]any) (export.Request, error) {
+	r, err := c.exportRequest(string(method.FullName()), request)
+	r.Desc = method
+	return r, err
+}
+
+func (c *Client) exportRequest(methodFullName string, request map[string]any) (export.Request, error) {
+	r := export.Request{
+		Method:         methodFullName,
+		Body:           request,
+		Target:         c.config.Target,
+		Unix:           c.config.Network == NetworkUnix,
+		Plaintext:      c.config.Creds.Info().SecurityProtocol == "insecure",
+		Authority:      c.config.Authority,
+		UserAgent:      c.config.UserAgent,
+		Protoset:       c.config.Protoset,
+		ProtoFiles:     c.config.ProtoFiles,
+		ImportPaths:    c.config.ImportPaths,
+		MaxRecvMsgSize: c.config.MaxRecvMsgSize,
+		KeepaliveTime:  c.config.KeepaliveTime,
 	}
-	if c.config.UserAgent != "" {
-		args = append(args, "-user-agent", c.config.UserAgent)
+	if c.config.Auth != nil {
+		token := redactedToken
+		if c.config.RevealTokens {
+			var err error
+			token, err = c.config.Auth.Token(context.Background())
+			if err != nil {
+				return export.Request{}, fmt.Errorf("failed to get token: %w", err)
+			}
+		}
+		r.Headers = append(r.Headers, "authorization: Bearer "+token)
 	}
-	args = append(args, "-d", string(jsonData), c.config.Target, methodFullName)
+	r.Headers = append(r.Headers, c.config.Headers...)
+	r.RPCHeaders = c.config.RPCHeaders
+	r.ReflectHeaders = c.config.ReflectHeaders
+	return r, nil
+}
 
-	for i, arg := range args {
-		args[i] = shellQuote(arg)
-	}
-	return strings.Join(args, " "), nil
+// reflectionHeaders returns the headers sent with reflection requests.
+func (c *Client) reflectionHeaders() []string {
+	return append(append([]string(nil), c.config.Headers...), c.config.ReflectHeaders...)
 }
 
-func shellQuote(s string) string {
-	if s != "" && strings.IndexFunc(s, func(r rune) bool {
-		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_+-=.,/:@", r)
-	}) == -1 {
-		return s
+// requestHeaders returns the configured headers and the outgoing metadata of
+// ctx as grpcurl-style headers. grpcurl replaces the outgoing metadata of the
+// context it is given, so metadata has to be passed this way.
+func (c *Client) requestHeaders(ctx context.Context) []string {
+	headers := append(append([]string(nil), c.config.Headers...), c.config.RPCHeaders...)
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range md[k] {
+			headers = append(headers, k+": "+v)
+		}
 	}
-	return "'" + strings.ReplaceAll(s, "'", "'\"'\"'") + "'"
+	return headers
 }
 
 func (c *Client) ListServices() ([]string, error) {
-	svcNames, err := c.source.ListServices()
+	svcNames, err := c.descriptorSource().ListServices()
 	if err != nil {
 		return nil, err
 	}
@@ -179,7 +397,7 @@ func (c *Client) ListServices() ([]string, error) {
 }
 
 func (c *Client) ListMethods(fullyQualifiedName string) ([]protoreflect.MethodDescriptor, error) {
-	descriptor, err := c.source.FindSymbol(fullyQualifiedName)
+	descriptor, err := c.descriptorSource().FindSymbol(fullyQualifiedName)
 	if err != nil {
 		return nil, err
 	}
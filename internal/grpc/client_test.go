@@ -1,9 +1,17 @@
 package grpc
 
 import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/prnvbn/grpcexp/cmd/testserver/server"
+	grpclib "google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestGRPCURLCommand(t *testing.T) {
@@ -28,3 +36,141 @@ func TestGRPCURLCommand(t *testing.T) {
 		t.Fatalf("GRPCURLCommand = %q, want %q", got, want)
 	}
 }
+
+func TestGRPCURLCommandOptions(t *testing.T) {
+	network, socket := ParseTarget("unix:///tmp/echo.sock")
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{
+			name:   "redacted token",
+			config: Config{Auth: &StaticToken{Value: "secret"}},
+			want:   `grpcurl -plaintext -H 'authorization: Bearer <redacted>' -d '{}' localhost:50051 echo.v1.EchoService.Echo`,
+		},
+		{
+			name:   "revealed token",
+			config: Config{Auth: &StaticToken{Value: "secret"}, RevealTokens: true},
+			want:   `grpcurl -plaintext -H 'authorization: Bearer secret' -d '{}' localhost:50051 echo.v1.EchoService.Echo`,
+		},
+		{
+			name:   "headers",
+			config: Config{Auth: &StaticToken{Value: "secret"}, Headers: []string{"x-user: pranav"}},
+			want:   `grpcurl -plaintext -H 'authorization: Bearer <redacted>' -H 'x-user: pranav' -d '{}' localhost:50051 echo.v1.EchoService.Echo`,
+		},
+		{
+			name:   "rpc and reflection headers",
+			config: Config{RPCHeaders: []string{"x-rpc: 1"}, ReflectHeaders: []string{"x-reflect: 1"}},
+			want:   `grpcurl -plaintext -rpc-header 'x-rpc: 1' -reflect-header 'x-reflect: 1' -d '{}' localhost:50051 echo.v1.EchoService.Echo`,
+		},
+		{
+			name:   "unix socket",
+			config: Config{Target: socket, Network: network},
+			want:   `grpcurl -plaintext -unix -d '{}' /tmp/echo.sock echo.v1.EchoService.Echo`,
+		},
+		{
+			name:   "connection options",
+			config: Config{Authority: "echo.internal", MaxRecvMsgSize: 16 << 20, KeepaliveTime: 1500 * time.Millisecond},
+			want:   `grpcurl -plaintext -authority echo.internal -max-msg-sz 16777216 -keepalive-time 1.5 -d '{}' localhost:50051 echo.v1.EchoService.Echo`,
+		},
+		{
+			name:   "proto files",
+			config: Config{ProtoFiles: []string{"echo/echo.proto"}, ImportPaths: []string{"proto", "third_party"}},
+			want:   `grpcurl -plaintext -import-path proto -import-path third_party -proto echo/echo.proto -d '{}' localhost:50051 echo.v1.EchoService.Echo`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			if config.Target == "" {
+				config.Target = "localhost:50051"
+			}
+			config.Creds = insecure.NewCredentials()
+			client := &Client{config: config}
+
+			got, err := client.GRPCURLCommand("echo.v1.EchoService.Echo", map[string]any{})
+			if err != nil {
+				t.Fatalf("GRPCURLCommand returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("GRPCURLCommand = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadersReachReflection(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string][]string)
+	record := func(ctx context.Context, method string) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, name := range []string{"x-all", "x-rpc", "x-reflect"} {
+			if len(md.Get(name)) > 0 && !slices.Contains(seen[method], name) {
+				seen[method] = append(seen[method], name)
+			}
+		}
+	}
+	dialer := server.Start(t,
+		grpclib.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+			record(ctx, info.FullMethod)
+			return handler(ctx, req)
+		}),
+		grpclib.ChainStreamInterceptor(func(srv any, ss grpclib.ServerStream, _ *grpclib.StreamServerInfo, handler grpclib.StreamHandler) error {
+			record(ss.Context(), "reflection")
+			return handler(srv, ss)
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := NewClient(ctx, Config{
+		Target:         "bufnet",
+		Dialer:         dialer,
+		Creds:          insecure.NewCredentials(),
+		Headers:        []string{"x-all: 1"},
+		RPCHeaders:     []string{"x-rpc: 1"},
+		ReflectHeaders: []string{"x-reflect: 1"},
+	})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	defer client.Close() //nolint:errcheck // test client
+
+	if _, err := client.InvokeRPC(ctx, "helloworld.Greeter.SayHello", map[string]any{"name": "headers"}); err != nil {
+		t.Fatalf("InvokeRPC returned error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := map[string][]string{
+		"reflection":                   {"x-all", "x-reflect"},
+		"/helloworld.Greeter/SayHello": {"x-all", "x-rpc"},
+	}
+	if !reflect.DeepEqual(seen, want) {
+		t.Fatalf("headers seen = %v, want %v", seen, want)
+	}
+}
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		target      string
+		wantNetwork string
+		wantAddress string
+	}{
+		{target: "localhost:50051", wantNetwork: NetworkTCP, wantAddress: "localhost:50051"},
+		{target: "unix:///var/run/app.sock", wantNetwork: NetworkUnix, wantAddress: "/var/run/app.sock"},
+		{target: "unix:app.sock", wantNetwork: NetworkUnix, wantAddress: "app.sock"},
+		{target: "unix-abstract:app", wantNetwork: NetworkUnix, wantAddress: "@app"},
+	}
+
+	for _, tt := range tests {
+		network, address := ParseTarget(tt.target)
+		if network != tt.wantNetwork || address != tt.wantAddress {
+			t.Errorf("ParseTarget(%q) = %q, %q, want %q, %q", tt.target, network, address, tt.wantNetwork, tt.wantAddress)
+		}
+	}
+}
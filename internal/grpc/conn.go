@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fullstorydev/grpcurl"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+const (
+	lazyDialAttempts       = 3
+	lazyDialAttemptTimeout = 5 * time.Second
+	lazyDialBackoff        = 500 * time.Millisecond
+)
+
+// Target returns the address the client is connected to.
+func (c *Client) Target() string {
+	if c.config.Network == NetworkUnix {
+		return "unix:" + c.config.Target
+	}
+	return c.config.Target
+}
+
+// Connected reports whether the client has a connection. It is false for
+// clients started offline until the first successful lazy connect.
+func (c *Client) Connected() bool {
+	return c.clientConn() != nil
+}
+
+// DialError returns the error from the last failed connection attempt while offline.
+func (c *Client) DialError() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dialErr
+}
+
+// State returns the current connectivity state of the underlying connection.
+// Clients that have not connected yet report Idle.
+func (c *Client) State() connectivity.State {
+	cc := c.clientConn()
+	if cc == nil {
+		return connectivity.Idle
+	}
+	return cc.GetState()
+}
+
+// WaitForStateChange blocks until the connection leaves state or ctx is done.
+// It reports whether the state changed. While offline it waits for the client
+// to connect.
+func (c *Client) WaitForStateChange(ctx context.Context, state connectivity.State) bool {
+	cc := c.clientConn()
+	if cc == nil {
+		select {
+		case <-c.connected:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return cc.WaitForStateChange(ctx, state)
+}
+
+// Reconnect forces the connection to reconnect immediately, waits for it to
+// become ready and, when descriptors come from server reflection, re-fetches them.
+func (c *Client) Reconnect(ctx context.Context) error {
+	cc := c.clientConn()
+	if cc == nil {
+		_, err := c.clientConnOrDial(ctx)
+		return err
+	}
+
+	cc.ResetConnectBackoff()
+	cc.Connect()
+
+	for state := cc.GetState(); state != connectivity.Ready; state = cc.GetState() {
+		if !cc.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("failed to reconnect: %w", ctx.Err())
+		}
+	}
+
+	if c.localDescriptors() {
+		return nil
+	}
+	return c.refreshDescriptors(cc)
+}
+
+// Close closes the connection. The client can't be used afterwards.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refClient != nil {
+		c.refClient.Reset()
+	}
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// ForwardConn dials a new connection with the client's settings for
+// forwarding other clients' calls. It leaves out Auth so the credentials the
+// callers send reach the server unchanged. The caller closes it.
+func (c *Client) ForwardConn(ctx context.Context) (*grpc.ClientConn, error) {
+	config := c.config
+	config.Auth = nil
+	return grpcurl.BlockingDial(ctx, config.Network, config.Target, config.Creds, dialOptions(config)...)
+}
+
+func (c *Client) clientConn() *grpc.ClientConn {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.conn
+}
+
+// clientConnOrDial returns the connection, dialing with retries if the client
+// was started offline.
+func (c *Client) clientConnOrDial(ctx context.Context) (*grpc.ClientConn, error) {
+	if cc := c.clientConn(); cc != nil {
+		return cc, nil
+	}
+
+	c.dialMu.Lock()
+	defer c.dialMu.Unlock()
+
+	// another call may have connected while we waited for the lock
+	if cc := c.clientConn(); cc != nil {
+		return cc, nil
+	}
+
+	var err error
+	backoff := lazyDialBackoff
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, lazyDialAttemptTimeout)
+		var cc *grpc.ClientConn
+		cc, err = c.dial(attemptCtx)
+		cancel()
+		if err == nil {
+			c.setConn(cc)
+			return cc, nil
+		}
+
+		if attempt == lazyDialAttempts || ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+		}
+		backoff *= 2
+	}
+
+	c.mu.Lock()
+	c.dialErr = err
+	c.mu.Unlock()
+	return nil, fmt.Errorf("failed to connect to %s: %w", c.Target(), err)
+}
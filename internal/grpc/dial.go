@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	NetworkTCP  = "tcp"
+	NetworkUnix = "unix"
+)
+
+// ParseTarget detects unix:// and unix-abstract: targets and returns the
+// network to dial along with the address in that network. Any other target
+// is returned unchanged as a TCP address.
+func ParseTarget(target string) (network, address string) {
+	switch {
+	case strings.HasPrefix(target, "unix://"):
+		return NetworkUnix, strings.TrimPrefix(target, "unix://")
+	case strings.HasPrefix(target, "unix-abstract:"):
+		// a leading @ selects the abstract namespace on linux
+		return NetworkUnix, "@" + strings.TrimPrefix(target, "unix-abstract:")
+	case strings.HasPrefix(target, "unix:"):
+		return NetworkUnix, strings.TrimPrefix(target, "unix:")
+	default:
+		return NetworkTCP, target
+	}
+}
+
+func dialOptions(config Config) []grpc.DialOption {
+	opts := []grpc.DialOption{
+		grpc.WithUserAgent(config.UserAgent),
+	}
+
+	authority := config.Authority
+	switch {
+	case config.Dialer != nil:
+		opts = append(opts, grpc.WithContextDialer(config.Dialer))
+	case config.Network == NetworkUnix:
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, NetworkUnix, addr)
+		}))
+		// the socket path is not a meaningful :authority
+		if authority == "" {
+			authority = "localhost"
+		}
+	}
+	if authority != "" {
+		opts = append(opts, grpc.WithAuthority(authority))
+	}
+
+	// per-RPC credentials cover reflection as well as the calls themselves
+	if config.Auth != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(config.Auth))
+	}
+
+	if config.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    config.KeepaliveTime,
+			Timeout: config.KeepaliveTimeout,
+		}))
+	}
+	if config.InitialWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialWindowSize(config.InitialWindowSize))
+	}
+	if config.InitialConnWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialConnWindowSize(config.InitialConnWindowSize))
+	}
+
+	if callOpts := callOptions(config); len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+
+	return opts
+}
+
+func callOptions(config Config) []grpc.CallOption {
+	var opts []grpc.CallOption
+	if config.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxCallRecvMsgSize(config.MaxRecvMsgSize))
+	}
+	if config.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxCallSendMsgSize(config.MaxSendMsgSize))
+	}
+	if config.Gzip {
+		opts = append(opts, grpc.UseCompressor(gzip.Name))
+	}
+	return opts
+}
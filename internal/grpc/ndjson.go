@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxNDJSONLine bounds a single message in a newline-delimited JSON stream.
+const maxNDJSONLine = 64 << 20
+
+// ReadNDJSON decodes newline-delimited JSON objects, one request per line.
+// Blank lines are skipped.
+func ReadNDJSON(r io.Reader) ([]map[string]any, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+
+	var requests []map[string]any
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var request map[string]any
+		if err := json.Unmarshal(data, &request); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		requests = append(requests, request)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
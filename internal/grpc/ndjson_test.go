@@ -0,0 +1,24 @@
+package grpc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadNDJSON(t *testing.T) {
+	requests, err := ReadNDJSON(strings.NewReader("{\"name\":\"a\"}\n\n  {\"name\":\"b\"}  \n"))
+	if err != nil {
+		t.Fatalf("ReadNDJSON returned error: %v", err)
+	}
+
+	if len(requests) != 2 || requests[0]["name"] != "a" || requests[1]["name"] != "b" {
+		t.Fatalf("ReadNDJSON = %v, want names a and b", requests)
+	}
+}
+
+func TestReadNDJSONReportsLine(t *testing.T) {
+	_, err := ReadNDJSON(strings.NewReader("{\"name\":\"a\"}\n{\"name\":\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Fatalf("ReadNDJSON error = %v, want line 2 error", err)
+	}
+}
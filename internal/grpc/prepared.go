@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fullstorydev/grpcurl"
+	"github.com/jhump/protoreflect/desc"                //nolint:staticcheck // Deprecated package but required by grpcurl
+	"github.com/jhump/protoreflect/dynamic"             //nolint:staticcheck // Deprecated package but required by grpcurl
+	"github.com/jhump/protoreflect/dynamic/grpcdynamic" //nolint:staticcheck // Deprecated package but required by grpcurl
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryCall sends a prepared request to a unary method. The error carries the
+// gRPC status of failed calls.
+type UnaryCall func(ctx context.Context) error
+
+// PrepareUnary encodes request once and returns a call that sends it over the
+// client's connection, for sending the same request many times.
+func (c *Client) PrepareUnary(ctx context.Context, methodFullName string, request map[string]any) (UnaryCall, error) {
+	source := c.descriptorSource()
+	md, err := findMethod(source, methodFullName)
+	if err != nil {
+		return nil, err
+	}
+	if md.IsClientStreaming() || md.IsServerStreaming() {
+		return nil, fmt.Errorf("%s is a streaming method", methodFullName)
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	rf, _, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, source, bytes.NewReader(jsonData), grpcurl.FormatOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request parser: %w", err)
+	}
+	req := dynamic.NewMessage(md.GetInputType())
+	if err := rf.Next(req); err != nil {
+		return nil, fmt.Errorf("failed to parse request: %w", err)
+	}
+
+	cc, err := c.clientConnOrDial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stub := grpcdynamic.NewStub(cc)
+
+	return func(ctx context.Context) error {
+		if headers := c.requestHeaders(ctx); len(headers) > 0 {
+			ctx = metadata.NewOutgoingContext(ctx, grpcurl.MetadataFromHeaders(headers))
+		}
+		_, err := stub.InvokeRpc(ctx, md, req)
+		return err
+	}, nil
+}
+
+func findMethod(source grpcurl.DescriptorSource, methodFullName string) (*desc.MethodDescriptor, error) {
+	dot := strings.LastIndex(methodFullName, ".")
+	if dot < 0 {
+		return nil, fmt.Errorf("invalid method name %q", methodFullName)
+	}
+
+	descriptor, err := source.FindSymbol(methodFullName[:dot])
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := descriptor.(*desc.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("service descriptor not found for %s", methodFullName[:dot])
+	}
+	md := sd.FindMethodByName(methodFullName[dot+1:])
+	if md == nil {
+		return nil, fmt.Errorf("method %s not found", methodFullName)
+	}
+	return md, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/fullstorydev/grpcurl"
 	oldproto "github.com/golang/protobuf/proto" //nolint:staticcheck // grpcurl uses the legacy proto API
 	"github.com/jhump/protoreflect/desc"        //nolint:staticcheck // Deprecated package but required by grpcurl
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
@@ -16,12 +17,21 @@ const (
 	StreamEventResponse StreamEventKind = iota
 	StreamEventError
 	StreamEventClosed
+	// StreamEventHeaders carries the response headers.
+	StreamEventHeaders
+	// StreamEventTrailers carries the response trailers and final status. It
+	// is followed by a closed or error event.
+	StreamEventTrailers
+	// StreamEventSentHeaders carries the request headers the stream started with.
+	StreamEventSentHeaders
 )
 
 type StreamEvent struct {
-	Kind    StreamEventKind
-	Message string
-	Err     error
+	Kind     StreamEventKind
+	Message  string
+	Err      error
+	Metadata metadata.MD
+	Status   *status.Status
 }
 
 var _ grpcurl.InvocationEventHandler = &streamEventHandler{}
@@ -35,9 +45,13 @@ type streamEventHandler struct {
 
 func (h *streamEventHandler) OnResolveMethod(_ *desc.MethodDescriptor) {}
 
-func (h *streamEventHandler) OnSendHeaders(_ metadata.MD) {}
+func (h *streamEventHandler) OnSendHeaders(md metadata.MD) {
+	h.events <- StreamEvent{Kind: StreamEventSentHeaders, Metadata: md}
+}
 
-func (h *streamEventHandler) OnReceiveHeaders(_ metadata.MD) {}
+func (h *streamEventHandler) OnReceiveHeaders(md metadata.MD) {
+	h.events <- StreamEvent{Kind: StreamEventHeaders, Metadata: md}
+}
 
 func (h *streamEventHandler) OnReceiveResponse(resp oldproto.Message) {
 	h.count++
@@ -49,6 +63,11 @@ func (h *streamEventHandler) OnReceiveResponse(resp oldproto.Message) {
 	h.events <- StreamEvent{Kind: StreamEventResponse, Message: respStr}
 }
 
-func (h *streamEventHandler) OnReceiveTrailers(stat *status.Status, _ metadata.MD) {
+func (h *streamEventHandler) OnReceiveTrailers(stat *status.Status, md metadata.MD) {
+	if stat == nil {
+		// grpcurl reports a successful stream with a nil status
+		stat = status.New(codes.OK, "")
+	}
 	h.status = stat
+	h.events <- StreamEvent{Kind: StreamEventTrailers, Metadata: md, Status: stat}
 }
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fullstorydev/grpcurl"
+	"github.com/jhump/protoreflect/desc" //nolint:staticcheck // Deprecated package but required by grpcurl
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// ListSymbols returns every method, message and enum known to the descriptor
+// source, sorted by full name. Synthetic map entry messages are skipped.
+func (c *Client) ListSymbols() ([]protoreflect.Descriptor, error) {
+	files, err := grpcurl.GetAllFiles(c.descriptorSource())
+	if err != nil && len(files) == 0 {
+		return nil, err
+	}
+
+	var symbols []protoreflect.Descriptor
+	for _, fd := range files {
+		file := fd.UnwrapFile()
+
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				symbols = append(symbols, methods.Get(j))
+			}
+		}
+		symbols = appendTypes(symbols, file.Messages(), file.Enums())
+	}
+
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i].FullName() < symbols[j].FullName()
+	})
+	return symbols, nil
+}
+
+// FindMethod looks up a method by its full name, e.g. helloworld.Greeter.SayHello.
+func (c *Client) FindMethod(methodFullName string) (protoreflect.MethodDescriptor, error) {
+	md, err := findMethod(c.descriptorSource(), methodFullName)
+	if err != nil {
+		return nil, err
+	}
+	return md.UnwrapMethod(), nil
+}
+
+func appendTypes(symbols []protoreflect.Descriptor, messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors) []protoreflect.Descriptor {
+	for i := 0; i < enums.Len(); i++ {
+		symbols = append(symbols, enums.Get(i))
+	}
+	for i := 0; i < messages.Len(); i++ {
+		msg := messages.Get(i)
+		if !msg.IsMapEntry() {
+			symbols = append(symbols, msg)
+		}
+		symbols = appendTypes(symbols, msg.Messages(), msg.Enums())
+	}
+	return symbols
+}
+
+// Files returns every file known to the descriptor source as a registry.
+func (c *Client) Files() (*protoregistry.Files, error) {
+	files, err := grpcurl.GetAllFiles(c.descriptorSource())
+	if err != nil {
+		return nil, err
+	}
+
+	registry := new(protoregistry.Files)
+	seen := make(map[string]bool)
+	var register func(fd *desc.FileDescriptor) error
+	register = func(fd *desc.FileDescriptor) error {
+		if seen[fd.GetName()] {
+			return nil
+		}
+		seen[fd.GetName()] = true
+		for _, dep := range fd.GetDependencies() {
+			if err := register(dep); err != nil {
+				return err
+			}
+		}
+		if err := registry.RegisterFile(fd.UnwrapFile()); err != nil {
+			return fmt.Errorf("failed to register %s: %w", fd.GetName(), err)
+		}
+		return nil
+	}
+	for _, fd := range files {
+		if err := register(fd); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
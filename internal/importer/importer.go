@@ -0,0 +1,215 @@
+// Package importer converts the gRPC requests and environments saved by other
+// clients, Postman and Insomnia, into collections and profiles.
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/prnvbn/grpcexp/internal/collection"
+	"github.com/prnvbn/grpcexp/internal/profile"
+)
+
+// Result is what an export was converted into.
+type Result struct {
+	Collection collection.Collection
+	// Profiles hold the variables of the export's environments.
+	Profiles profile.Profiles
+	// Skipped describes what couldn't be converted, one item per entry.
+	Skipped []string
+}
+
+// Read converts a Postman collection or environment export, or an Insomnia
+// export, telling them apart by their contents.
+func Read(r io.Reader) (Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read export: %w", err)
+	}
+
+	var probe struct {
+		Type   string          `json:"_type"`
+		Format int             `json:"__export_format"`
+		Info   json.RawMessage `json:"info"`
+		Values json.RawMessage `json:"values"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return Result{}, fmt.Errorf("failed to read export: %w", err)
+	}
+	switch {
+	case probe.Type == "export":
+		if probe.Format != 4 {
+			return Result{}, fmt.Errorf("unsupported Insomnia export format %d, export as Insomnia v4 JSON", probe.Format)
+		}
+		return insomnia(data)
+	case probe.Info != nil:
+		return postmanCollection(data)
+	case probe.Values != nil:
+		return postmanEnvironment(data)
+	default:
+		return Result{}, fmt.Errorf("not a Postman or Insomnia export")
+	}
+}
+
+// Merge adds the requests, variables and profiles of other to r. The name of
+// r's collection is kept if it has one.
+func (r *Result) Merge(other Result) {
+	if r.Collection.Name == "" {
+		r.Collection.Name = other.Collection.Name
+	}
+	r.Collection.Requests = append(r.Collection.Requests, other.Collection.Requests...)
+	if len(other.Collection.Vars) > 0 {
+		if r.Collection.Vars == nil {
+			r.Collection.Vars = map[string]any{}
+		}
+		maps.Copy(r.Collection.Vars, other.Collection.Vars)
+	}
+	if len(other.Profiles) > 0 {
+		if r.Profiles == nil {
+			r.Profiles = profile.Profiles{}
+		}
+		maps.Copy(r.Profiles, other.Profiles)
+	}
+	r.Skipped = append(r.Skipped, other.Skipped...)
+}
+
+// converter rewrites the template syntax of a client into placeholders and
+// reports what it couldn't rewrite.
+type converter struct {
+	rewrite func(s string) (string, []string)
+	skipped []string
+	// item names the request or environment being converted in reports
+	item string
+}
+
+func (c *converter) skip(format string, args ...any) {
+	skipped := c.item + ": " + fmt.Sprintf(format, args...)
+	if !slices.Contains(c.skipped, skipped) {
+		c.skipped = append(c.skipped, skipped)
+	}
+}
+
+func (c *converter) text(s string) string {
+	s, problems := c.rewrite(s)
+	for _, p := range problems {
+		c.skip("%s", p)
+	}
+	return s
+}
+
+// value rewrites the strings in a decoded JSON value.
+func (c *converter) value(v any) any {
+	switch v := v.(type) {
+	case string:
+		return c.text(v)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			out[c.text(key)] = c.value(value)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			out[i] = c.value(value)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// body decodes a request message written as JSON text. Placeholders standing
+// for whole values, as in {"count": {{count}}}, are quoted first.
+func (c *converter) body(text string) map[string]any {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	var body map[string]any
+	if err := json.Unmarshal([]byte(quotePlaceholders(text)), &body); err != nil {
+		c.skip("message is not a JSON object: %v", err)
+		return nil
+	}
+	converted, _ := c.value(body).(map[string]any)
+	return converted
+}
+
+// vars converts variables, flattening nested objects into dotted names.
+func (c *converter) vars(values map[string]any) map[string]any {
+	vars := map[string]any{}
+	var flatten func(prefix string, values map[string]any)
+	flatten = func(prefix string, values map[string]any) {
+		for name, value := range values {
+			if nested, ok := value.(map[string]any); ok {
+				flatten(prefix+name+".", nested)
+				continue
+			}
+			vars[prefix+name] = c.value(value)
+		}
+	}
+	flatten("", values)
+	return vars
+}
+
+// method turns the paths clients store, e.g. /helloworld.Greeter/SayHello,
+// into full method names.
+func method(path string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(path, "/"), "/", ".")
+}
+
+// targets reports the servers the requests were saved with, which
+// collections leave to the connection flags.
+func targets(byTarget map[string]int) []string {
+	var skipped []string
+	for _, target := range slices.Sorted(maps.Keys(byTarget)) {
+		if target == "" {
+			continue
+		}
+		requests := "requests"
+		if byTarget[target] == 1 {
+			requests = "request"
+		}
+		skipped = append(skipped, fmt.Sprintf("server %s of %d %s: connect to it with --addr", target, byTarget[target], requests))
+	}
+	return skipped
+}
+
+var leadingPlaceholder = regexp.MustCompile(`^\{\{[^{}]*\}\}`)
+
+// quotePlaceholders quotes the placeholders outside of JSON strings.
+func quotePlaceholders(text string) string {
+	var out strings.Builder
+	inString, escaped := false, false
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			}
+			out.WriteByte(ch)
+			continue
+		}
+		if ch == '"' {
+			inString = true
+		}
+		if ch == '{' {
+			if placeholder := leadingPlaceholder.FindString(text[i:]); placeholder != "" {
+				out.WriteString(`"` + placeholder + `"`)
+				i += len(placeholder) - 1
+				continue
+			}
+		}
+		out.WriteByte(ch)
+	}
+	return out.String()
+}
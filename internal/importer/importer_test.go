@@ -0,0 +1,214 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prnvbn/grpcexp/internal/collection"
+	"github.com/prnvbn/grpcexp/internal/profile"
+)
+
+const postmanExport = `{
+  "info": {"name": "greeter", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "variable": [
+    {"key": "user", "value": "pranav"},
+    {"key": "unused", "value": "x", "disabled": true}
+  ],
+  "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}"}]},
+  "item": [
+    {
+      "name": "hello",
+      "item": [
+        {
+          "name": "SayHello",
+          "request": {
+            "url": "localhost:50051",
+            "methodPath": "helloworld.Greeter/SayHello",
+            "message": "{\"name\": \"{{user}}\", \"id\": \"{{$guid}}\", \"count\": {{count}}}",
+            "metadata": [
+              {"key": "X-Tenant", "value": "acme"},
+              {"key": "x-off", "value": "1", "disabled": true}
+            ]
+          }
+        }
+      ]
+    },
+    {
+      "name": "Chat",
+      "event": [{"listen": "test", "script": {"exec": ["pm.test()"]}}],
+      "request": {
+        "url": "localhost:50051",
+        "methodPath": "/hello.v1.HelloService/HelloStream",
+        "message": {"name": "{{$randomColor}}"},
+        "auth": {"type": "noauth"}
+      }
+    },
+    {"name": "Health", "request": {"method": "GET", "url": {"raw": "http://localhost/health"}}}
+  ]
+}`
+
+func TestReadPostmanCollection(t *testing.T) {
+	got, err := Read(strings.NewReader(postmanExport))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	want := collection.Collection{
+		Name: "greeter",
+		Vars: map[string]any{"user": "pranav"},
+		Requests: []collection.Request{
+			{
+				Name:     "hello / SayHello",
+				Method:   "helloworld.Greeter.SayHello",
+				Metadata: map[string]string{"x-tenant": "acme", "authorization": "Bearer {{token}}"},
+				Body:     map[string]any{"name": "{{user}}", "id": "{{uuid}}", "count": "{{count}}"},
+			},
+			{
+				Name:   "Chat",
+				Method: "hello.v1.HelloService.HelloStream",
+				Body:   map[string]any{"name": "{{$randomColor}}"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got.Collection, want) {
+		t.Errorf("Collection = %#v, want %#v", got.Collection, want)
+	}
+
+	wantSkipped := []string{
+		"Chat: scripts are not imported",
+		"Chat: dynamic variable $randomColor has no placeholder and is kept as is",
+		"Health: not a gRPC request",
+		"server localhost:50051 of 2 requests: connect to it with --addr",
+	}
+	if !reflect.DeepEqual(got.Skipped, wantSkipped) {
+		t.Errorf("Skipped = %q, want %q", got.Skipped, wantSkipped)
+	}
+}
+
+func TestReadPostmanEnvironment(t *testing.T) {
+	got, err := Read(strings.NewReader(`{
+  "name": "staging",
+  "values": [
+    {"key": "token", "value": "secret", "enabled": true},
+    {"key": "count", "value": 3, "enabled": true},
+    {"key": "old", "value": "x", "enabled": false}
+  ],
+  "_postman_variable_scope": "environment"
+}`))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	want := profile.Profiles{"staging": {Vars: map[string]any{"token": "secret", "count": 3.0}}}
+	if !reflect.DeepEqual(got.Profiles, want) {
+		t.Errorf("Profiles = %v, want %v", got.Profiles, want)
+	}
+}
+
+func TestReadInsomnia(t *testing.T) {
+	got, err := Read(strings.NewReader(`{
+  "_type": "export",
+  "__export_format": 4,
+  "resources": [
+    {"_id": "wrk_1", "_type": "workspace", "name": "greeter"},
+    {"_id": "fld_1", "_type": "request_group", "parentId": "wrk_1", "name": "users"},
+    {
+      "_id": "greq_1", "_type": "grpc_request", "parentId": "fld_1", "name": "Get user",
+      "url": "{{ _.host }}", "protoMethodName": "/users.v1.UserService/GetUser",
+      "body": {"text": "{\"id\": {{ _.user.id }}, \"request_id\": \"{% uuid 'v4' %}\", \"at\": \"{% now 'millis' %}\"}"},
+      "metadata": [{"name": "x-tenant", "value": "{{ _.tenant }}"}, {"name": "x-off", "value": "1", "disabled": true}]
+    },
+    {"_id": "greq_2", "_type": "grpc_request", "parentId": "wrk_1", "name": "Unpicked", "url": "localhost:50051"},
+    {"_id": "req_1", "_type": "request", "parentId": "wrk_1", "name": "Health"},
+    {"_id": "pf_1", "_type": "proto_file", "parentId": "wrk_1", "name": "users.proto"},
+    {"_id": "env_1", "_type": "environment", "parentId": "wrk_1", "name": "Base Environment", "data": {"tenant": "acme"}},
+    {"_id": "env_2", "_type": "environment", "parentId": "env_1", "name": "staging", "data": {"host": "staging:443", "user": {"id": 42}}}
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	want := collection.Collection{
+		Name: "greeter",
+		Vars: map[string]any{"tenant": "acme"},
+		Requests: []collection.Request{{
+			Name:     "users / Get user",
+			Method:   "users.v1.UserService.GetUser",
+			Metadata: map[string]string{"x-tenant": "{{tenant}}"},
+			Body:     map[string]any{"id": "{{user.id}}", "request_id": "{{uuid}}", "at": "{% now 'millis' %}"},
+		}},
+	}
+	if !reflect.DeepEqual(got.Collection, want) {
+		t.Errorf("Collection = %#v, want %#v", got.Collection, want)
+	}
+
+	wantProfiles := profile.Profiles{"staging": {Vars: map[string]any{"host": "staging:443", "user.id": 42.0}}}
+	if !reflect.DeepEqual(got.Profiles, wantProfiles) {
+		t.Errorf("Profiles = %v, want %v", got.Profiles, wantProfiles)
+	}
+
+	wantSkipped := []string{
+		"users / Get user: template tag now has no placeholder and is kept as is",
+		"Unpicked: no method is selected",
+		"Health: not a gRPC request",
+		"users.proto: proto files are not imported, load them with --proto",
+		"server {{ _.host }} of 1 request: connect to it with --addr",
+	}
+	if !reflect.DeepEqual(got.Skipped, wantSkipped) {
+		t.Errorf("Skipped = %q, want %q", got.Skipped, wantSkipped)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		export string
+		want   string
+	}{
+		{`{"_type": "export", "__export_format": 5}`, "unsupported Insomnia export format 5"},
+		{`{"name": "x"}`, "not a Postman or Insomnia export"},
+		{`{"values": []}`, "has no name"},
+		{`[`, "failed to read export"},
+	}
+	for _, tt := range tests {
+		_, err := Read(strings.NewReader(tt.export))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Read(%s) error = %v, want %q", tt.export, err, tt.want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	var r Result
+	r.Merge(Result{
+		Collection: collection.Collection{Name: "greeter", Requests: []collection.Request{{Method: "a.B.C"}}},
+		Skipped:    []string{"one"},
+	})
+	r.Merge(Result{
+		Collection: collection.Collection{Name: "other", Vars: map[string]any{"user": "pranav"}},
+		Profiles:   profile.Profiles{"staging": {}},
+		Skipped:    []string{"two"},
+	})
+
+	want := Result{
+		Collection: collection.Collection{
+			Name:     "greeter",
+			Vars:     map[string]any{"user": "pranav"},
+			Requests: []collection.Request{{Method: "a.B.C"}},
+		},
+		Profiles: profile.Profiles{"staging": {}},
+		Skipped:  []string{"one", "two"},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("Merge = %#v, want %#v", r, want)
+	}
+}
+
+func TestQuotePlaceholders(t *testing.T) {
+	got := quotePlaceholders(`{"a": {{n}}, "b": "{{s}} }}", "c": [{{x}}], "d": {"e": 1}}`)
+	want := `{"a": "{{n}}", "b": "{{s}} }}", "c": ["{{x}}"], "d": {"e": 1}}`
+	if got != want {
+		t.Errorf("quotePlaceholders = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prnvbn/grpcexp/internal/collection"
+	"github.com/prnvbn/grpcexp/internal/profile"
+)
+
+// insomniaResource is one entry of an Insomnia v4 export. Resources refer to
+// the workspace, folder or environment they belong to by parent id.
+type insomniaResource struct {
+	ID       string `json:"_id"`
+	Type     string `json:"_type"`
+	ParentID string `json:"parentId"`
+	Name     string `json:"name"`
+
+	URL             string `json:"url"`
+	ProtoMethodName string `json:"protoMethodName"`
+	Body            struct {
+		Text string `json:"text"`
+	} `json:"body"`
+	Metadata []struct {
+		Name     string `json:"name"`
+		Value    string `json:"value"`
+		Disabled bool   `json:"disabled"`
+	} `json:"metadata"`
+
+	Data map[string]any `json:"data"`
+}
+
+var (
+	insomniaVariablePattern = regexp.MustCompile(`\{\{\s*_\.([^{}]*?)\s*\}\}`)
+	insomniaTagPattern      = regexp.MustCompile(`\{%\s*(\w+)([^%]*)%\}`)
+)
+
+// rewriteInsomnia rewrites variables such as {{ _.user_id }} and the
+// template tags that have a placeholder.
+func rewriteInsomnia(s string) (string, []string) {
+	s = insomniaVariablePattern.ReplaceAllString(s, "{{$1}}")
+
+	var problems []string
+	s = insomniaTagPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := insomniaTagPattern.FindStringSubmatch(match)
+		tag, args := m[1], strings.TrimSpace(m[2])
+		switch {
+		case tag == "uuid":
+			return "{{uuid}}"
+		case tag == "now" && (args == "" || strings.Contains(args, "iso-8601")):
+			return "{{now}}"
+		}
+		problems = append(problems, fmt.Sprintf("template tag %s has no placeholder and is kept as is", tag))
+		return match
+	})
+	return s, problems
+}
+
+func insomnia(data []byte) (Result, error) {
+	var export struct {
+		Resources []insomniaResource `json:"resources"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return Result{}, fmt.Errorf("failed to read Insomnia export: %w", err)
+	}
+	byID := make(map[string]insomniaResource, len(export.Resources))
+	for _, res := range export.Resources {
+		byID[res.ID] = res
+	}
+
+	c := &converter{rewrite: rewriteInsomnia}
+	var result Result
+	byTarget := map[string]int{}
+	for _, res := range export.Resources {
+		switch res.Type {
+		case "workspace":
+			if result.Collection.Name == "" {
+				result.Collection.Name = res.Name
+			}
+		case "grpc_request":
+			c.item = insomniaPath(res, byID)
+			if res.ProtoMethodName == "" {
+				c.skip("no method is selected")
+				continue
+			}
+			req := collection.Request{
+				Name:   c.item,
+				Method: method(res.ProtoMethodName),
+				Body:   c.body(res.Body.Text),
+			}
+			for _, md := range res.Metadata {
+				if md.Disabled || md.Name == "" {
+					continue
+				}
+				if req.Metadata == nil {
+					req.Metadata = map[string]string{}
+				}
+				req.Metadata[strings.ToLower(c.text(md.Name))] = c.text(md.Value)
+			}
+			result.Collection.Requests = append(result.Collection.Requests, req)
+			byTarget[res.URL]++
+		case "request", "websocket_request":
+			c.item = insomniaPath(res, byID)
+			c.skip("not a gRPC request")
+		case "proto_file":
+			c.item = res.Name
+			c.skip("proto files are not imported, load them with --proto")
+		case "environment":
+			c.item = "environment " + res.Name
+			vars := c.vars(res.Data)
+			if byID[res.ParentID].Type != "environment" {
+				// the base environment holds the defaults of the workspace
+				if len(vars) > 0 {
+					result.Collection.Vars = vars
+				}
+				continue
+			}
+			if result.Profiles == nil {
+				result.Profiles = profile.Profiles{}
+			}
+			result.Profiles[res.Name] = profile.Profile{Vars: vars}
+		}
+	}
+
+	result.Skipped = append(c.skipped, targets(byTarget)...)
+	return result, nil
+}
+
+// insomniaPath names a request after the folders it is in, e.g.
+// "users / Get user".
+func insomniaPath(res insomniaResource, byID map[string]insomniaResource) string {
+	name := res.Name
+	seen := map[string]bool{}
+	for parent, ok := byID[res.ParentID]; ok && parent.Type == "request_group" && !seen[parent.ID]; parent, ok = byID[parent.ParentID] {
+		seen[parent.ID] = true
+		name = parent.Name + " / " + name
+	}
+	return name
+}
@@ -0,0 +1,240 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prnvbn/grpcexp/internal/collection"
+	"github.com/prnvbn/grpcexp/internal/profile"
+)
+
+type postmanItem struct {
+	Name string `json:"name"`
+	// Item holds the items of a folder, which has no request.
+	Item    []postmanItem     `json:"item"`
+	Request json.RawMessage   `json:"request"`
+	Event   []json.RawMessage `json:"event"`
+}
+
+// postmanRequest is a gRPC request. HTTP requests have a method and an URL
+// object instead of a method path.
+type postmanRequest struct {
+	URL        json.RawMessage   `json:"url"`
+	MethodPath string            `json:"methodPath"`
+	Message    json.RawMessage   `json:"message"`
+	Metadata   []postmanKeyValue `json:"metadata"`
+	Auth       *postmanAuth      `json:"auth"`
+}
+
+type postmanKeyValue struct {
+	Key      string `json:"key"`
+	Value    any    `json:"value"`
+	Disabled bool   `json:"disabled"`
+	// Enabled is used instead of Disabled by environments
+	Enabled *bool `json:"enabled"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Bearer []postmanKeyValue `json:"bearer"`
+	APIKey []postmanKeyValue `json:"apikey"`
+}
+
+// postmanDynamic maps the dynamic variables of Postman to placeholders.
+var postmanDynamic = map[string]string{
+	"guid":         "{{uuid}}",
+	"randomUUID":   "{{uuid}}",
+	"isoTimestamp": "{{now}}",
+	"randomInt":    "{{randInt 0 1000}}",
+}
+
+var postmanDynamicPattern = regexp.MustCompile(`\{\{\s*\$(\w+)\s*\}\}`)
+
+// rewritePostman rewrites dynamic variables such as {{$guid}}. Other
+// variables are written like placeholders already.
+func rewritePostman(s string) (string, []string) {
+	var problems []string
+	s = postmanDynamicPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := postmanDynamicPattern.FindStringSubmatch(match)[1]
+		if placeholder, ok := postmanDynamic[name]; ok {
+			return placeholder
+		}
+		problems = append(problems, fmt.Sprintf("dynamic variable $%s has no placeholder and is kept as is", name))
+		return match
+	})
+	return s, problems
+}
+
+func postmanCollection(data []byte) (Result, error) {
+	var export struct {
+		Info struct {
+			Name string `json:"name"`
+		} `json:"info"`
+		Item     []postmanItem     `json:"item"`
+		Variable []postmanKeyValue `json:"variable"`
+		Auth     *postmanAuth      `json:"auth"`
+		Event    []json.RawMessage `json:"event"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return Result{}, fmt.Errorf("failed to read Postman collection: %w", err)
+	}
+
+	c := &converter{rewrite: rewritePostman, item: export.Info.Name}
+	result := Result{Collection: collection.Collection{Name: export.Info.Name}}
+	if vars := c.keyValues(export.Variable); len(vars) > 0 {
+		result.Collection.Vars = vars
+	}
+	if len(export.Event) > 0 {
+		c.skip("scripts are not imported")
+	}
+
+	byTarget := map[string]int{}
+	var walk func(prefix string, items []postmanItem)
+	walk = func(prefix string, items []postmanItem) {
+		for _, item := range items {
+			c.item = prefix + item.Name
+			if len(item.Event) > 0 {
+				c.skip("scripts are not imported")
+			}
+			if item.Request == nil {
+				walk(c.item+" / ", item.Item)
+				continue
+			}
+
+			var r postmanRequest
+			if err := json.Unmarshal(item.Request, &r); err != nil || r.MethodPath == "" {
+				c.skip("not a gRPC request")
+				continue
+			}
+			req := collection.Request{
+				Name:   c.item,
+				Method: method(r.MethodPath),
+				Body:   c.postmanMessage(r.Message),
+			}
+			md := map[string]string{}
+			for _, kv := range r.Metadata {
+				if !kv.Disabled && kv.Key != "" {
+					md[strings.ToLower(c.text(kv.Key))] = c.text(valueText(kv.Value))
+				}
+			}
+			auth := export.Auth
+			if r.Auth != nil {
+				auth = r.Auth
+			}
+			c.postmanAuth(auth, md)
+			if len(md) > 0 {
+				req.Metadata = md
+			}
+			result.Collection.Requests = append(result.Collection.Requests, req)
+
+			// gRPC requests keep their server as a plain string
+			var target string
+			_ = json.Unmarshal(r.URL, &target)
+			byTarget[target]++
+		}
+	}
+	walk("", export.Item)
+
+	result.Skipped = append(c.skipped, targets(byTarget)...)
+	return result, nil
+}
+
+func postmanEnvironment(data []byte) (Result, error) {
+	var env struct {
+		Name   string            `json:"name"`
+		Values []postmanKeyValue `json:"values"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Result{}, fmt.Errorf("failed to read Postman environment: %w", err)
+	}
+	if env.Name == "" {
+		return Result{}, fmt.Errorf("the Postman environment has no name")
+	}
+
+	c := &converter{rewrite: rewritePostman, item: "environment " + env.Name}
+	return Result{
+		Profiles: profile.Profiles{env.Name: {Vars: c.keyValues(env.Values)}},
+		Skipped:  c.skipped,
+	}, nil
+}
+
+// postmanMessage decodes a message saved as JSON text or as an object.
+func (c *converter) postmanMessage(raw json.RawMessage) map[string]any {
+	var message any
+	if len(raw) == 0 || json.Unmarshal(raw, &message) != nil {
+		return nil
+	}
+	switch message := message.(type) {
+	case nil:
+		return nil
+	case string:
+		return c.body(message)
+	case map[string]any:
+		converted, _ := c.value(message).(map[string]any)
+		return converted
+	default:
+		c.skip("message is not a JSON object")
+		return nil
+	}
+}
+
+// postmanAuth adds the metadata auth sends to md.
+func (c *converter) postmanAuth(auth *postmanAuth, md map[string]string) {
+	if auth == nil {
+		return
+	}
+	switch auth.Type {
+	case "", "noauth":
+	case "bearer":
+		if token, ok := lookup(auth.Bearer, "token"); ok {
+			md["authorization"] = "Bearer " + c.text(token)
+		}
+	case "apikey":
+		if in, _ := lookup(auth.APIKey, "in"); in == "query" {
+			c.skip("API keys in the query are not imported")
+			return
+		}
+		key, _ := lookup(auth.APIKey, "key")
+		value, _ := lookup(auth.APIKey, "value")
+		if key != "" {
+			md[strings.ToLower(c.text(key))] = c.text(value)
+		}
+	default:
+		c.skip("%s auth is not imported", auth.Type)
+	}
+}
+
+// keyValues converts the enabled variables of a collection or environment.
+func (c *converter) keyValues(kvs []postmanKeyValue) map[string]any {
+	vars := map[string]any{}
+	for _, kv := range kvs {
+		if kv.Disabled || kv.Enabled != nil && !*kv.Enabled || kv.Key == "" {
+			continue
+		}
+		vars[kv.Key] = c.value(kv.Value)
+	}
+	return vars
+}
+
+func lookup(kvs []postmanKeyValue, key string) (string, bool) {
+	for _, kv := range kvs {
+		if kv.Key == key {
+			return valueText(kv.Value), true
+		}
+	}
+	return "", false
+}
+
+func valueText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -0,0 +1,153 @@
+// Package jsonpath evaluates a small subset of JSONPath against decoded JSON:
+// $ followed by .name, ['name'], [index] and [*] steps. The leading $ may be
+// left out, so "session.id" and "$.session.id" are the same path.
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Path is a parsed path.
+type Path struct {
+	text  string
+	steps []step
+}
+
+type step struct {
+	key   string
+	index int
+	// isIndex selects a list element instead of a map key
+	isIndex bool
+	// wildcard selects every element or value
+	wildcard bool
+}
+
+// Parse parses a path such as $.items[0].name.
+func Parse(text string) (Path, error) {
+	p := Path{text: text}
+	rest := strings.TrimSpace(text)
+	if after, ok := strings.CutPrefix(rest, "$"); ok {
+		rest = after
+	} else if rest != "" && rest[0] != '[' {
+		rest = "." + rest
+	}
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			if name == "" {
+				return Path{}, fmt.Errorf("invalid path %q: empty name", text)
+			}
+			if name == "*" {
+				p.steps = append(p.steps, step{wildcard: true})
+			} else {
+				p.steps = append(p.steps, step{key: name})
+			}
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return Path{}, fmt.Errorf("invalid path %q: unclosed [", text)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				p.steps = append(p.steps, step{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				p.steps = append(p.steps, step{key: inner[1 : len(inner)-1]})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return Path{}, fmt.Errorf("invalid path %q: bad index %q", text, inner)
+				}
+				p.steps = append(p.steps, step{index: index, isIndex: true})
+			}
+		default:
+			return Path{}, fmt.Errorf("invalid path %q: unexpected %q", text, rest[0])
+		}
+	}
+	return p, nil
+}
+
+func (p Path) String() string {
+	return p.text
+}
+
+// Get returns the value at the path in doc. It reports false when the path
+// doesn't exist or has a wildcard.
+func (p Path) Get(doc any) (any, bool) {
+	value := doc
+	for _, s := range p.steps {
+		switch {
+		case s.wildcard:
+			return nil, false
+		case s.isIndex:
+			list, ok := value.([]any)
+			if !ok || s.index >= len(list) {
+				return nil, false
+			}
+			value = list[s.index]
+		default:
+			m, ok := value.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if value, ok = m[s.key]; !ok {
+				return nil, false
+			}
+		}
+	}
+	return value, true
+}
+
+// Delete removes what the path selects from doc in place. Map keys are
+// removed and list elements are set to nil so the other indexes still hold.
+func (p Path) Delete(doc any) {
+	if len(p.steps) == 0 {
+		return
+	}
+	deleteSteps(doc, p.steps)
+}
+
+func deleteSteps(value any, steps []step) {
+	s, last := steps[0], len(steps) == 1
+	switch v := value.(type) {
+	case map[string]any:
+		if s.isIndex {
+			return
+		}
+		for key := range v {
+			if !s.wildcard && key != s.key {
+				continue
+			}
+			if last {
+				delete(v, key)
+			} else {
+				deleteSteps(v[key], steps[1:])
+			}
+		}
+	case []any:
+		if !s.isIndex && !s.wildcard {
+			return
+		}
+		for i := range v {
+			if !s.wildcard && i != s.index {
+				continue
+			}
+			if last {
+				v[i] = nil
+			} else {
+				deleteSteps(v[i], steps[1:])
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	var doc any
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		t.Fatalf("invalid JSON %s: %v", s, err)
+	}
+	return doc
+}
+
+func TestGet(t *testing.T) {
+	doc := decode(t, `{"session": {"id": "abc"}, "items": [{"name": "a"}, {"name": "b"}], "odd key": 1}`)
+
+	tests := []struct {
+		path string
+		want any
+		ok   bool
+	}{
+		{path: "$.session.id", want: "abc", ok: true},
+		{path: "session.id", want: "abc", ok: true},
+		{path: "$.items[1].name", want: "b", ok: true},
+		{path: "$['odd key']", want: float64(1), ok: true},
+		{path: "$", want: doc, ok: true},
+		{path: "$.items[2]", ok: false},
+		{path: "$.session.missing", ok: false},
+		{path: "$.items[*].name", ok: false},
+	}
+	for _, tt := range tests {
+		p, err := Parse(tt.path)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tt.path, err)
+		}
+		got, ok := p.Get(doc)
+		if ok != tt.ok || (ok && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("Get(%q) = %v, %v, want %v, %v", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDelete(t *testing.T) {
+	doc := decode(t, `{"id": 1, "items": [{"name": "a", "time": 1}, {"name": "b", "time": 2}], "tags": ["x", "y"]}`)
+	for _, path := range []string{"id", "$.items[*].time", "$.tags[0]", "$.missing.key"} {
+		p, err := Parse(path)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", path, err)
+		}
+		p.Delete(doc)
+	}
+
+	want := decode(t, `{"items": [{"name": "a"}, {"name": "b"}], "tags": [null, "y"]}`)
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("after Delete doc = %v, want %v", doc, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, path := range []string{"$.", "$.a[", "$.a[-1]", "$.a[x]", "$x"} {
+		if _, err := Parse(path); err == nil {
+			t.Errorf("Parse(%q) returned no error", path)
+		}
+	}
+}
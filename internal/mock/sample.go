@@ -0,0 +1,106 @@
+package mock
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// maxSampleDepth stops sample data from following recursive messages forever.
+const maxSampleDepth = 3
+
+// Sample returns a message of type md with every field set to a plausible
+// value: strings hold the field name, numbers are 1, repeated fields and maps
+// have one element and only the first field of each oneof is set.
+func Sample(md protoreflect.MessageDescriptor) *dynamicpb.Message {
+	msg := dynamicpb.NewMessage(md)
+	fillSample(msg, 0)
+	return msg
+}
+
+func fillSample(msg protoreflect.Message, depth int) {
+	md := msg.Descriptor()
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		now := time.Now()
+		msg.Set(md.Fields().ByName("seconds"), protoreflect.ValueOfInt64(now.Unix()))
+		return
+	case "google.protobuf.Duration":
+		msg.Set(md.Fields().ByName("seconds"), protoreflect.ValueOfInt64(1))
+		return
+	}
+	if depth >= maxSampleDepth {
+		return
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if oneof := fd.ContainingOneof(); oneof != nil && oneof.Fields().Get(0) != fd {
+			continue
+		}
+		if fd.Message() != nil && fd.Message().FullName() == "google.protobuf.Any" {
+			// an Any needs a resolvable type, leave it unset
+			continue
+		}
+
+		switch {
+		case fd.IsMap():
+			m := msg.Mutable(fd).Map()
+			key := sampleScalar(fd.MapKey()).MapKey()
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				value := m.NewValue()
+				fillSample(value.Message(), depth+1)
+				m.Set(key, value)
+			} else {
+				m.Set(key, sampleScalar(fd.MapValue()))
+			}
+		case fd.IsList():
+			list := msg.Mutable(fd).List()
+			if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+				if depth+1 < maxSampleDepth {
+					value := list.NewElement()
+					fillSample(value.Message(), depth+1)
+					list.Append(value)
+				}
+			} else {
+				list.Append(sampleScalar(fd))
+			}
+		case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+			fillSample(msg.Mutable(fd).Message(), depth+1)
+		default:
+			msg.Set(fd, sampleScalar(fd))
+		}
+	}
+}
+
+func sampleScalar(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		// prefer the first value that isn't the zero "unspecified" value
+		if values.Len() > 1 {
+			return protoreflect.ValueOfEnum(values.Get(1).Number())
+		}
+		return protoreflect.ValueOfEnum(values.Get(0).Number())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(1)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(1)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(1)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(1)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(1.5)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(1.5)
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(fd.Name()))
+	default:
+		return protoreflect.ValueOfString(string(fd.Name()))
+	}
+}
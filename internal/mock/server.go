@@ -0,0 +1,357 @@
+// Package mock serves services from their descriptors alone, answering calls
+// from stubs or with sample data.
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	v1alphareflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Server answers every method of the services in its files.
+type Server struct {
+	files    *protoregistry.Files
+	types    *dynamicpb.Types
+	services []protoreflect.ServiceDescriptor
+	stubs    map[protoreflect.FullName][]stub
+
+	// Logf, if set, is told how each call was answered.
+	Logf func(format string, args ...any)
+}
+
+// stub is a Stub with its responses parsed into messages.
+type stub struct {
+	match     map[string]any
+	responses []proto.Message
+	err       error
+	delay     time.Duration
+}
+
+// NewServer serves every service in files except server reflection, which
+// the server provides itself. It fails if a stub names an unknown method or
+// has a response that doesn't fit the method's output type.
+func NewServer(files *protoregistry.Files, stubs Stubs) (*Server, error) {
+	s := &Server{
+		files: files,
+		types: dynamicpb.NewTypes(files),
+		stubs: make(map[protoreflect.FullName][]stub),
+	}
+
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			if !strings.HasPrefix(string(services.Get(i).FullName()), "grpc.reflection.") {
+				s.services = append(s.services, services.Get(i))
+			}
+		}
+		return true
+	})
+
+	for name, methodStubs := range stubs {
+		md, err := s.findMethod(name)
+		if err != nil {
+			return nil, err
+		}
+		for i, st := range methodStubs {
+			compiled, err := s.compile(md, st)
+			if err != nil {
+				return nil, fmt.Errorf("stub %d of %s: %w", i+1, name, err)
+			}
+			s.stubs[md.FullName()] = append(s.stubs[md.FullName()], compiled)
+		}
+	}
+	return s, nil
+}
+
+// Services returns the full names of the services the server answers.
+func (s *Server) Services() []string {
+	names := make([]string, len(s.services))
+	for i, sd := range s.services {
+		names[i] = string(sd.FullName())
+	}
+	return names
+}
+
+// Register registers the services and server reflection on gs.
+func (s *Server) Register(gs *grpc.Server) {
+	for _, sd := range s.services {
+		gs.RegisterService(s.serviceDesc(sd), s)
+	}
+
+	opts := reflection.ServerOptions{
+		Services:           gs,
+		DescriptorResolver: s.files,
+		ExtensionResolver:  extensionTypes(s.files),
+	}
+	v1reflectiongrpc.RegisterServerReflectionServer(gs, reflection.NewServerV1(opts))
+	v1alphareflectiongrpc.RegisterServerReflectionServer(gs, reflection.NewServer(opts))
+}
+
+func (s *Server) findMethod(name string) (protoreflect.MethodDescriptor, error) {
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 {
+		return nil, fmt.Errorf("invalid method name %q", name)
+	}
+	d, err := s.files.FindDescriptorByName(protoreflect.FullName(name[:dot]))
+	if err != nil {
+		return nil, fmt.Errorf("unknown service for method %s", name)
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", name[:dot])
+	}
+	md := sd.Methods().ByName(protoreflect.Name(name[dot+1:]))
+	if md == nil {
+		return nil, fmt.Errorf("unknown method %s", name)
+	}
+	return md, nil
+}
+
+func (s *Server) compile(md protoreflect.MethodDescriptor, st Stub) (stub, error) {
+	compiled := stub{match: st.Match, delay: time.Duration(st.Delay)}
+	if st.Error != nil {
+		code := codes.Code(st.Error.Code)
+		// a status of OK is no error, so the call would answer with nothing
+		if code == codes.OK {
+			return stub{}, fmt.Errorf("error needs a code other than OK")
+		}
+		compiled.err = status.Error(code, st.Error.Message)
+		return compiled, nil
+	}
+
+	responses := st.Responses
+	if st.Response != nil {
+		responses = append([]map[string]any{st.Response}, responses...)
+	}
+	for _, response := range responses {
+		data, err := json.Marshal(response)
+		if err != nil {
+			return stub{}, err
+		}
+		msg := dynamicpb.NewMessage(md.Output())
+		if err := (protojson.UnmarshalOptions{Resolver: s.types}).Unmarshal(data, msg); err != nil {
+			return stub{}, fmt.Errorf("invalid %s: %w", md.Output().FullName(), err)
+		}
+		compiled.responses = append(compiled.responses, msg)
+	}
+	return compiled, nil
+}
+
+func (s *Server) serviceDesc(sd protoreflect.ServiceDescriptor) *grpc.ServiceDesc {
+	desc := &grpc.ServiceDesc{
+		ServiceName: string(sd.FullName()),
+		HandlerType: (*any)(nil),
+		Metadata:    sd.ParentFile().Path(),
+	}
+
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		if !md.IsStreamingClient() && !md.IsStreamingServer() {
+			desc.Methods = append(desc.Methods, grpc.MethodDesc{
+				MethodName: string(md.Name()),
+				Handler:    s.unaryHandler(md),
+			})
+			continue
+		}
+		desc.Streams = append(desc.Streams, grpc.StreamDesc{
+			StreamName:    string(md.Name()),
+			Handler:       s.streamHandler(md),
+			ServerStreams: md.IsStreamingServer(),
+			ClientStreams: md.IsStreamingClient(),
+		})
+	}
+	return desc
+}
+
+func (s *Server) unaryHandler(md protoreflect.MethodDescriptor) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := dynamicpb.NewMessage(md.Input())
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+
+		handle := func(ctx context.Context, req any) (any, error) {
+			responses, err := s.answer(ctx, md, req.(proto.Message))
+			if err != nil {
+				return nil, err
+			}
+			return first(md, responses), nil
+		}
+		if interceptor == nil {
+			return handle(ctx, req)
+		}
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod(md)}
+		return interceptor(ctx, req, info, handle)
+	}
+}
+
+func (s *Server) streamHandler(md protoreflect.MethodDescriptor) grpc.StreamHandler {
+	return func(_ any, stream grpc.ServerStream) error {
+		ctx := stream.Context()
+
+		switch {
+		case md.IsStreamingClient() && md.IsStreamingServer():
+			// answer each request as it arrives
+			for {
+				req := dynamicpb.NewMessage(md.Input())
+				if err := stream.RecvMsg(req); err != nil {
+					if errors.Is(err, io.EOF) {
+						return nil
+					}
+					return err
+				}
+				responses, err := s.answer(ctx, md, req)
+				if err != nil {
+					return err
+				}
+				if err := sendAll(stream, responses); err != nil {
+					return err
+				}
+			}
+		case md.IsStreamingClient():
+			// answer once the client is done, matching the last request
+			last := dynamicpb.NewMessage(md.Input())
+			for {
+				req := dynamicpb.NewMessage(md.Input())
+				err := stream.RecvMsg(req)
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				last = req
+			}
+			responses, err := s.answer(ctx, md, last)
+			if err != nil {
+				return err
+			}
+			return stream.SendMsg(first(md, responses))
+		default:
+			req := dynamicpb.NewMessage(md.Input())
+			if err := stream.RecvMsg(req); err != nil {
+				return err
+			}
+			responses, err := s.answer(ctx, md, req)
+			if err != nil {
+				return err
+			}
+			return sendAll(stream, responses)
+		}
+	}
+}
+
+// answer returns the responses of the first stub matching req, or sample data
+// when no stub matches.
+func (s *Server) answer(ctx context.Context, md protoreflect.MethodDescriptor, req proto.Message) ([]proto.Message, error) {
+	st, ok := s.find(md, req)
+	if !ok {
+		s.logf("%s: no matching stub, answering with sample data", md.FullName())
+		return []proto.Message{Sample(md.Output())}, nil
+	}
+
+	if st.delay > 0 {
+		select {
+		case <-time.After(st.delay):
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if st.err != nil {
+		s.logf("%s: answering with %v", md.FullName(), st.err)
+		return nil, st.err
+	}
+	s.logf("%s: answering with %d stubbed message(s)", md.FullName(), len(st.responses))
+	return st.responses, nil
+}
+
+func (s *Server) find(md protoreflect.MethodDescriptor, req proto.Message) (stub, bool) {
+	stubs := s.stubs[md.FullName()]
+	if len(stubs) == 0 {
+		return stub{}, false
+	}
+
+	// match against both proto and JSON field names
+	protoNames := s.requestJSON(req, true)
+	jsonNames := s.requestJSON(req, false)
+	for _, st := range stubs {
+		if matches(st.match, protoNames) || matches(st.match, jsonNames) {
+			return st, true
+		}
+	}
+	return stub{}, false
+}
+
+func (s *Server) requestJSON(req proto.Message, protoNames bool) map[string]any {
+	opts := protojson.MarshalOptions{UseProtoNames: protoNames, EmitUnpopulated: true, Resolver: s.types}
+	request := map[string]any{}
+	if data, err := opts.Marshal(req); err == nil {
+		_ = json.Unmarshal(data, &request)
+	}
+	return request
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.Logf != nil {
+		s.Logf(format, args...)
+	}
+}
+
+// first returns the response of a call with a single response, which is
+// empty if the stub has none.
+func first(md protoreflect.MethodDescriptor, responses []proto.Message) proto.Message {
+	if len(responses) == 0 {
+		return dynamicpb.NewMessage(md.Output())
+	}
+	return responses[0]
+}
+
+func sendAll(stream grpc.ServerStream, responses []proto.Message) error {
+	for _, response := range responses {
+		if err := stream.SendMsg(response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fullMethod(md protoreflect.MethodDescriptor) string {
+	return "/" + string(md.Parent().FullName()) + "/" + string(md.Name())
+}
+
+// extensionTypes collects the extensions declared in files for reflection.
+func extensionTypes(files *protoregistry.Files) *protoregistry.Types {
+	types := new(protoregistry.Types)
+	var register func(xds protoreflect.ExtensionDescriptors, mds protoreflect.MessageDescriptors)
+	register = func(xds protoreflect.ExtensionDescriptors, mds protoreflect.MessageDescriptors) {
+		for i := 0; i < xds.Len(); i++ {
+			// a conflicting extension only hides one from reflection
+			_ = types.RegisterExtension(dynamicpb.NewExtensionType(xds.Get(i)))
+		}
+		for i := 0; i < mds.Len(); i++ {
+			register(mds.Get(i).Extensions(), mds.Get(i).Messages())
+		}
+	}
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		register(fd.Extensions(), fd.Messages())
+		return true
+	})
+	return types
+}
@@ -0,0 +1,199 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	featurev1 "github.com/prnvbn/grpcexp/cmd/testserver/feature"
+	hellov1 "github.com/prnvbn/grpcexp/cmd/testserver/hello"
+	"github.com/prnvbn/grpcexp/cmd/testserver/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func newTestServer(t *testing.T, stubs Stubs) *grpc.ClientConn {
+	t.Helper()
+
+	files := new(protoregistry.Files)
+	for _, fd := range []protoreflect.FileDescriptor{
+		helloworldpb.File_examples_helloworld_helloworld_helloworld_proto,
+		hellov1.File_cmd_testserver_hello_hello_proto,
+		featurev1.File_cmd_testserver_feature_feature_proto,
+	} {
+		if err := files.RegisterFile(fd); err != nil {
+			t.Fatalf("RegisterFile returned error: %v", err)
+		}
+	}
+
+	mockServer, err := NewServer(files, stubs)
+	if err != nil {
+		t.Fatalf("NewServer returned error: %v", err)
+	}
+
+	s := grpc.NewServer()
+	mockServer.Register(s)
+	return server.Serve(t, s)
+}
+
+func TestUnaryStubs(t *testing.T) {
+	cc := newTestServer(t, Stubs{
+		"helloworld.Greeter.SayHello": {
+			{Match: map[string]any{"name": "Pranav"}, Response: map[string]any{"message": "Hi Pranav"}},
+			{Match: map[string]any{"name": "bad"}, Error: &StubError{Code: Code(codes.NotFound), Message: "no such user"}},
+		},
+	})
+	client := helloworldpb.NewGreeterClient(cc)
+	ctx := context.Background()
+
+	reply, err := client.SayHello(ctx, &helloworldpb.HelloRequest{Name: "Pranav"})
+	if err != nil || reply.GetMessage() != "Hi Pranav" {
+		t.Fatalf("SayHello(Pranav) = %v, %v, want Hi Pranav", reply, err)
+	}
+
+	_, err = client.SayHello(ctx, &helloworldpb.HelloRequest{Name: "bad"})
+	if status.Code(err) != codes.NotFound || status.Convert(err).Message() != "no such user" {
+		t.Fatalf("SayHello(bad) error = %v, want NotFound", err)
+	}
+
+	// no stub matches, so the reply is sample data
+	reply, err = client.SayHello(ctx, &helloworldpb.HelloRequest{Name: "someone"})
+	if err != nil || reply.GetMessage() != "message" {
+		t.Fatalf("SayHello(someone) = %v, %v, want sample data", reply, err)
+	}
+}
+
+func TestStreamStubs(t *testing.T) {
+	cc := newTestServer(t, Stubs{
+		"hello.v1.HelloService.Firehose": {
+			{Match: map[string]any{"count": 2}, Responses: []map[string]any{{"seq": 1}, {"seq": 2}}},
+		},
+		"hello.v1.HelloService.HelloStream": {
+			{Match: map[string]any{"name": "a"}, Response: map[string]any{"message": "A"}},
+		},
+	})
+	client := hellov1.NewHelloServiceClient(cc)
+	ctx := context.Background()
+
+	firehose, err := client.Firehose(ctx, &hellov1.FirehoseRequest{Count: 2})
+	if err != nil {
+		t.Fatalf("Firehose returned error: %v", err)
+	}
+	var seqs []uint64
+	for {
+		reply, err := firehose.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv returned error: %v", err)
+		}
+		seqs = append(seqs, reply.GetSeq())
+	}
+	if len(seqs) != 2 || seqs[0] != 1 || seqs[1] != 2 {
+		t.Fatalf("Firehose sent %v, want [1 2]", seqs)
+	}
+
+	bidi, err := client.HelloStream(ctx)
+	if err != nil {
+		t.Fatalf("HelloStream returned error: %v", err)
+	}
+	for _, want := range []struct{ name, message string }{{"a", "A"}, {"b", "message"}} {
+		if err := bidi.Send(&hellov1.HelloRequest{Name: want.name}); err != nil {
+			t.Fatalf("Send returned error: %v", err)
+		}
+		reply, err := bidi.Recv()
+		if err != nil || reply.GetMessage() != want.message {
+			t.Fatalf("HelloStream(%s) = %v, %v, want %s", want.name, reply, err, want.message)
+		}
+	}
+}
+
+func TestSampleWellKnownTypes(t *testing.T) {
+	cc := newTestServer(t, nil)
+
+	reply, err := featurev1.NewFeatureServiceClient(cc).WellKnownTypes(context.Background(), &featurev1.WellKnownMessage{})
+	if err != nil {
+		t.Fatalf("WellKnownTypes returned error: %v", err)
+	}
+	if reply.GetTimestamp().GetSeconds() == 0 || reply.GetStringValue().GetValue() != "value" || reply.GetAny() != nil {
+		t.Fatalf("WellKnownTypes = %v, want sample data", reply)
+	}
+}
+
+func TestNewServerRejectsBadStubs(t *testing.T) {
+	files := new(protoregistry.Files)
+	if err := files.RegisterFile(helloworldpb.File_examples_helloworld_helloworld_helloworld_proto); err != nil {
+		t.Fatalf("RegisterFile returned error: %v", err)
+	}
+
+	for name, stubs := range map[string]Stubs{
+		"unknown method":       {"helloworld.Greeter.SayGoodbye": {{}}},
+		"bad response":         {"helloworld.Greeter.SayHello": {{Response: map[string]any{"nope": 1}}}},
+		"error without a code": {"helloworld.Greeter.SayHello": {{Error: &StubError{Message: "x"}}}},
+		"error with code OK":   {"helloworld.Greeter.SayHello": {{Error: &StubError{Code: Code(codes.OK), Message: "x"}}}},
+	} {
+		if _, err := NewServer(files, stubs); err == nil {
+			t.Errorf("NewServer with %s returned no error", name)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	request := map[string]any{
+		"id":    "5",
+		"name":  "a",
+		"inner": map[string]any{"flag": true, "tags": []any{"x", "y"}},
+	}
+
+	tests := []struct {
+		match map[string]any
+		want  bool
+	}{
+		{match: nil, want: true},
+		{match: map[string]any{"id": float64(5)}, want: true},
+		{match: map[string]any{"inner": map[string]any{"flag": true}}, want: true},
+		{match: map[string]any{"inner": map[string]any{"tags": []any{"x", "y"}}}, want: true},
+		{match: map[string]any{"name": "b"}, want: false},
+		{match: map[string]any{"missing": "a"}, want: false},
+		{match: map[string]any{"inner": map[string]any{"tags": []any{"x"}}}, want: false},
+	}
+	for _, tt := range tests {
+		if got := matches(tt.match, request); got != tt.want {
+			t.Errorf("matches(%v) = %v, want %v", tt.match, got, tt.want)
+		}
+	}
+}
+
+func TestCodeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    codes.Code
+		wantErr bool
+	}{
+		{json: `0`, want: codes.OK},
+		{json: `5`, want: codes.NotFound},
+		{json: `16`, want: codes.Unauthenticated},
+		{json: `"NotFound"`, want: codes.NotFound},
+		{json: `17`, wantErr: true},
+		{json: `-1`, wantErr: true},
+		{json: `"Missing"`, wantErr: true},
+		{json: `true`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var got Code
+		err := json.Unmarshal([]byte(tt.json), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, want error %v", tt.json, err, tt.wantErr)
+			continue
+		}
+		if err == nil && codes.Code(got) != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.json, codes.Code(got), tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Stubs holds the canned responses of each method, keyed by the method's
+// full name, e.g. helloworld.Greeter.SayHello. The first stub whose Match
+// fits the request answers it.
+type Stubs map[string][]Stub
+
+type Stub struct {
+	// Match lists request fields and the values they must have. An empty
+	// Match fits every request.
+	Match map[string]any `json:"match,omitempty"`
+	// Response answers unary and client streaming calls.
+	Response map[string]any `json:"response,omitempty"`
+	// Responses are sent in order to server and bidi streaming calls.
+	Responses []map[string]any `json:"responses,omitempty"`
+	// Error fails the call instead.
+	Error *StubError `json:"error,omitempty"`
+	// Delay is waited before answering, e.g. "250ms".
+	Delay Duration `json:"delay,omitempty"`
+}
+
+type StubError struct {
+	// Code is a status code name such as "NotFound" or its number. It is
+	// required and can't be OK.
+	Code    Code   `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// Duration reads a duration string such as "1.5s" from JSON.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"250ms\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Code reads a status code from JSON by name or number.
+type Code codes.Code
+
+func (c *Code) UnmarshalJSON(data []byte) error {
+	var n uint32
+	if err := json.Unmarshal(data, &n); err == nil {
+		if codes.Code(n) > codes.Unauthenticated {
+			return fmt.Errorf("unknown status code %d", n)
+		}
+		*c = Code(n)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("code must be a status code name or number: %w", err)
+	}
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if code.String() == s {
+			*c = Code(code)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown status code %q", s)
+}
+
+// LoadStubs reads a stub file.
+func LoadStubs(path string) (Stubs, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var stubs Stubs
+	if err := json.Unmarshal(data, &stubs); err != nil {
+		return nil, fmt.Errorf("failed to read stubs: %w", err)
+	}
+	return stubs, nil
+}
+
+// matches reports whether every field in want has the same value in got.
+// Nested messages are matched the same way and scalars are compared by their
+// text so {"id": 5} matches an int64 field, which JSON encodes as "5".
+func matches(want, got map[string]any) bool {
+	for key, wantValue := range want {
+		gotValue, ok := got[key]
+		if !ok {
+			return false
+		}
+
+		switch wantValue := wantValue.(type) {
+		case map[string]any:
+			gotMap, ok := gotValue.(map[string]any)
+			if !ok || !matches(wantValue, gotMap) {
+				return false
+			}
+		case []any:
+			gotList, ok := gotValue.([]any)
+			if !ok || len(gotList) != len(wantValue) {
+				return false
+			}
+			for i := range wantValue {
+				if !matches(map[string]any{"": wantValue[i]}, map[string]any{"": gotList[i]}) {
+					return false
+				}
+			}
+		default:
+			if fmt.Sprint(wantValue) != fmt.Sprint(gotValue) {
+				return false
+			}
+		}
+	}
+	return true
+}
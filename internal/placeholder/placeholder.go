@@ -0,0 +1,172 @@
+// Package placeholder expands {{name}} placeholders in request payloads.
+//
+// The placeholders are {{seq}}, {{uuid}}, {{now}}, {{randInt min max}},
+// {{env.NAME}} and the names of variables in the context.
+package placeholder
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var pattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// Context holds the values placeholders resolve against.
+type Context struct {
+	// Seq is the 1-based number of the message being sent.
+	Seq int
+	// Now is the time used for {{now}}, time.Now if zero.
+	Now time.Time
+	// Vars are named values, such as ones captured from earlier responses. A
+	// string that is a single variable placeholder takes the variable's value
+	// as it is, so numbers and objects keep their type.
+	Vars map[string]any
+}
+
+// Expand returns a copy of request with placeholders replaced in every string
+// value. Unknown placeholders are left as they are.
+func Expand(request map[string]any, ctx Context) map[string]any {
+	expanded, _ := expandValue(request, ctx, true).(map[string]any)
+	return expanded
+}
+
+// ExpandText is like Expand but always replaces placeholders with text, for
+// requests whose values are all strings such as the ones the call builder
+// makes.
+func ExpandText(request map[string]any, ctx Context) map[string]any {
+	expanded, _ := expandValue(request, ctx, false).(map[string]any)
+	return expanded
+}
+
+// ExpandString replaces the placeholders in s.
+func ExpandString(s string, ctx Context) string {
+	return pattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := pattern.FindStringSubmatch(match)[1]
+		if value, ok := resolve(name, ctx); ok {
+			return value
+		}
+		return match
+	})
+}
+
+// Contains reports whether any string in v has a placeholder.
+func Contains(v any) bool {
+	switch v := v.(type) {
+	case string:
+		return pattern.MatchString(v)
+	case map[string]any:
+		for key, value := range v {
+			if pattern.MatchString(key) || Contains(value) {
+				return true
+			}
+		}
+	case []any:
+		for _, value := range v {
+			if Contains(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func expandValue(v any, ctx Context, typed bool) any {
+	switch v := v.(type) {
+	case string:
+		if m := pattern.FindStringSubmatch(v); typed && m != nil && m[0] == v {
+			if value, ok := ctx.Vars[m[1]]; ok {
+				return value
+			}
+		}
+		return ExpandString(v, ctx)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			out[ExpandString(key, ctx)] = expandValue(value, ctx, typed)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			out[i] = expandValue(value, ctx, typed)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func resolve(name string, ctx Context) (string, bool) {
+	if env, ok := strings.CutPrefix(name, "env."); ok {
+		return os.LookupEnv(env)
+	}
+	if args, ok := strings.CutPrefix(name, "randInt "); ok {
+		return randInt(args)
+	}
+	switch name {
+	case "seq":
+		return strconv.Itoa(ctx.Seq), true
+	case "uuid":
+		return newUUID(), true
+	case "now":
+		now := ctx.Now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		return now.UTC().Format(time.RFC3339Nano), true
+	}
+	if value, ok := ctx.Vars[name]; ok {
+		return varText(value), true
+	}
+	return "", false
+}
+
+// randInt returns a random integer between the bounds in args, inclusive.
+func randInt(args string) (string, bool) {
+	bounds := strings.Fields(args)
+	if len(bounds) != 2 {
+		return "", false
+	}
+	lo, err := strconv.ParseInt(bounds[0], 10, 64)
+	if err != nil {
+		return "", false
+	}
+	hi, err := strconv.ParseInt(bounds[1], 10, 64)
+	if err != nil || hi < lo {
+		return "", false
+	}
+	n, err := rand.Int(rand.Reader, new(big.Int).Add(big.NewInt(hi-lo), big.NewInt(1)))
+	if err != nil {
+		return "", false
+	}
+	return strconv.FormatInt(lo+n.Int64(), 10), true
+}
+
+// varText renders a variable inside a longer string: strings as they are and
+// anything else as JSON.
+func varText(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,107 @@
+package placeholder
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestExpand(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	request := map[string]any{
+		"id":      "msg-{{seq}}",
+		"sent_at": "{{ now }}",
+		"tags":    []any{"{{seq}}", "{{unknown}}"},
+		"nested":  map[string]any{"count": 3.0},
+	}
+
+	got := Expand(request, Context{Seq: 7, Now: now})
+
+	if got["id"] != "msg-7" {
+		t.Errorf("id = %v, want msg-7", got["id"])
+	}
+	if got["sent_at"] != "2024-01-02T03:04:05Z" {
+		t.Errorf("sent_at = %v, want 2024-01-02T03:04:05Z", got["sent_at"])
+	}
+	tags := got["tags"].([]any)
+	if tags[0] != "7" || tags[1] != "{{unknown}}" {
+		t.Errorf("tags = %v, want [7 {{unknown}}]", tags)
+	}
+	if got["nested"].(map[string]any)["count"] != 3.0 {
+		t.Errorf("nested = %v, want count 3", got["nested"])
+	}
+	if request["id"] != "msg-{{seq}}" {
+		t.Errorf("Expand modified its input: %v", request["id"])
+	}
+}
+
+func TestExpandUUID(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	a := ExpandString("{{uuid}}", Context{})
+	b := ExpandString("{{uuid}}", Context{})
+	if !uuid.MatchString(a) || !uuid.MatchString(b) {
+		t.Fatalf("ExpandString({{uuid}}) = %q, %q, want v4 UUIDs", a, b)
+	}
+	if a == b {
+		t.Fatalf("ExpandString({{uuid}}) returned %q twice", a)
+	}
+}
+
+func TestExpandVars(t *testing.T) {
+	ctx := Context{Vars: map[string]any{
+		"session_id": "abc",
+		"count":      float64(3),
+		"user":       map[string]any{"name": "a"},
+	}}
+	request := map[string]any{
+		"id":    "{{session_id}}",
+		"path":  "/sessions/{{ session_id }}/users/{{user}}",
+		"count": "{{count}}",
+		"user":  "{{user}}",
+		"other": "{{missing}}",
+	}
+
+	got := Expand(request, ctx)
+	if got["id"] != "abc" || got["count"] != float64(3) || got["other"] != "{{missing}}" {
+		t.Errorf("Expand = %v", got)
+	}
+	if got["path"] != `/sessions/abc/users/{"name":"a"}` {
+		t.Errorf("path = %v", got["path"])
+	}
+	if user, ok := got["user"].(map[string]any); !ok || user["name"] != "a" {
+		t.Errorf("user = %v, want the captured object", got["user"])
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("GRPCEXP_TEST_TOKEN", "secret")
+
+	got := ExpandString("Bearer {{env.GRPCEXP_TEST_TOKEN}} {{env.GRPCEXP_TEST_UNSET}}", Context{})
+	if want := "Bearer secret {{env.GRPCEXP_TEST_UNSET}}"; got != want {
+		t.Errorf("ExpandString = %q, want %q", got, want)
+	}
+}
+
+func TestExpandRandInt(t *testing.T) {
+	for range 100 {
+		got := ExpandString("{{randInt 1 3}}", Context{})
+		if got != "1" && got != "2" && got != "3" {
+			t.Fatalf("ExpandString({{randInt 1 3}}) = %q, want 1, 2 or 3", got)
+		}
+	}
+	for _, s := range []string{"{{randInt 3 1}}", "{{randInt 1}}", "{{randInt a b}}"} {
+		if got := ExpandString(s, Context{}); got != s {
+			t.Errorf("ExpandString(%s) = %q, want it unchanged", s, got)
+		}
+	}
+}
+
+func TestExpandText(t *testing.T) {
+	ctx := Context{Vars: map[string]any{"count": 3, "user": "a"}}
+
+	got := ExpandText(map[string]any{"count": "{{count}}", "user": "{{user}}"}, ctx)
+	if got["count"] != "3" || got["user"] != "a" {
+		t.Errorf("ExpandText = %#v, want the variables as text", got)
+	}
+}
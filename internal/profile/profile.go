@@ -0,0 +1,153 @@
+// Package profile reads named sets of variables that fill the {{name}}
+// placeholders of requests, such as the tenant and user ids of an environment.
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is a named set of variables, and the credentials of calls made
+// with them.
+type Profile struct {
+	Vars map[string]any `yaml:"vars"`
+	Auth *Auth          `yaml:"auth,omitempty"`
+}
+
+// Auth selects the provider of the bearer token sent with every call. Only
+// one of its fields may be set. Its values may use placeholders, e.g.
+// {{env.TOKEN}}, to keep secrets out of the file.
+type Auth struct {
+	// Token is a static token.
+	Token string `yaml:"token,omitempty"`
+	// Exec is a command that prints a token, plain or as JSON with token and
+	// expiry.
+	Exec string `yaml:"exec,omitempty"`
+	// OAuth2 fetches tokens with the client credentials flow.
+	OAuth2 *OAuth2 `yaml:"oauth2,omitempty"`
+}
+
+type OAuth2 struct {
+	TokenURL     string   `yaml:"token_url"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	Scopes       []string `yaml:"scopes,omitempty"`
+}
+
+// Profiles maps profile names to profiles:
+//
+//	staging:
+//	  vars:
+//	    tenant: acme
+//	    user_id: 42
+//	  auth:
+//	    exec: gcloud auth print-identity-token
+type Profiles map[string]Profile
+
+// DefaultPath returns the file profiles are read from by default.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "grpcexp", "profiles.yaml"), nil
+}
+
+// Read reads profiles. JSON is read as well since it is valid YAML.
+func Read(r io.Reader) (Profiles, error) {
+	var p Profiles
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+	return p, nil
+}
+
+// Load reads the profiles in the file at path. A missing file has no
+// profiles.
+func Load(path string) (Profiles, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Profiles{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+	return Read(f)
+}
+
+// Write writes profiles as YAML.
+func Write(w io.Writer, p Profiles) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(p); err != nil {
+		return fmt.Errorf("failed to write profiles: %w", err)
+	}
+	return enc.Close()
+}
+
+// Save writes p to the file at path, creating its directory if needed.
+// Profiles may hold credentials, so the file is only readable by the user. It
+// is written to a temporary file first so a failed write leaves the old
+// profiles in place.
+func Save(path string, p Profiles) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	// created with mode 0600
+	f, err := os.CreateTemp(dir, ".profiles-*.yaml")
+	if err != nil {
+		return err
+	}
+	if err := Write(f, p); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// Get returns the profile called name.
+func (p Profiles) Get(name string) (Profile, error) {
+	profile, ok := p[name]
+	if !ok {
+		names := make([]string, 0, len(p))
+		for name := range p {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return Profile{}, fmt.Errorf("no profile %q, no profiles are defined", name)
+		}
+		return Profile{}, fmt.Errorf("no profile %q, profiles are %s", name, strings.Join(names, ", "))
+	}
+	return profile, nil
+}
+
+// Vars returns the variables of the profile called name.
+func (p Profiles) Vars(name string) (map[string]any, error) {
+	profile, err := p.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return profile.Vars, nil
+}
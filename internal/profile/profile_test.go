@@ -0,0 +1,115 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	p, err := Read(strings.NewReader(`
+staging:
+  vars:
+    tenant: acme
+    user_id: 42
+local:
+  vars: {tenant: dev}
+`))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	vars, err := p.Vars("staging")
+	if err != nil {
+		t.Fatalf("Vars returned error: %v", err)
+	}
+	if want := map[string]any{"tenant": "acme", "user_id": 42}; !reflect.DeepEqual(vars, want) {
+		t.Errorf("Vars(staging) = %v, want %v", vars, want)
+	}
+
+	_, err = p.Vars("prod")
+	if err == nil || !strings.Contains(err.Error(), "profiles are local, staging") {
+		t.Errorf("Vars(prod) error = %v, want the defined profiles", err)
+	}
+}
+
+func TestReadUnknownField(t *testing.T) {
+	_, err := Read(strings.NewReader("staging:\n  variables: {tenant: acme}\n"))
+	if err == nil {
+		t.Fatal("Read returned no error for an unknown field")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	p, err := Load(filepath.Join(t.TempDir(), "profiles.yaml"))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if _, err := p.Vars("staging"); err == nil || !strings.Contains(err.Error(), "no profiles are defined") {
+		t.Errorf("Vars(staging) error = %v, want no profiles defined", err)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grpcexp", "profiles.yaml")
+	want := Profiles{"staging": {Vars: map[string]any{"tenant": "acme", "user_id": 42}}}
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load = %v, want %v", got, want)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat returned error: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("mode = %v, want 0600", mode)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("ReadDir returned error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d files, want only the profiles", len(entries))
+	}
+}
+
+func TestReadAuth(t *testing.T) {
+	p, err := Read(strings.NewReader(`
+staging:
+  auth:
+    exec: gcloud auth print-identity-token
+ci:
+  auth:
+    oauth2:
+      token_url: https://auth.example.com/token
+      client_id: grpcexp
+      client_secret: "{{env.CLIENT_SECRET}}"
+      scopes: [read]
+`))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	want := Profiles{
+		"staging": {Auth: &Auth{Exec: "gcloud auth print-identity-token"}},
+		"ci": {Auth: &Auth{OAuth2: &OAuth2{
+			TokenURL:     "https://auth.example.com/token",
+			ClientID:     "grpcexp",
+			ClientSecret: "{{env.CLIENT_SECRET}}",
+			Scopes:       []string{"read"},
+		}}},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Read = %+v, want %+v", p, want)
+	}
+}
@@ -0,0 +1,31 @@
+package proxy
+
+import "fmt"
+
+// frame is a message forwarded without being decoded.
+type frame []byte
+
+// rawCodec passes frames through untouched.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	f, ok := v.(*frame)
+	if !ok {
+		return nil, fmt.Errorf("proxy: cannot marshal %T", v)
+	}
+	return *f, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	f, ok := v.(*frame)
+	if !ok {
+		return fmt.Errorf("proxy: cannot unmarshal into %T", v)
+	}
+	*f = append((*f)[:0], data...)
+	return nil
+}
+
+// Name is "proto" so calls keep the application/grpc+proto content type.
+func (rawCodec) Name() string {
+	return "proto"
+}
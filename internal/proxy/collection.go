@@ -0,0 +1,47 @@
+package proxy
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/prnvbn/grpcexp/internal/collection"
+)
+
+// Request returns a request that replays the call. Binary and grpc- metadata
+// is left out, as are messages that couldn't be decoded.
+func (c Call) Request() collection.Request {
+	req := collection.Request{Method: c.Method}
+
+	for k, v := range c.Metadata {
+		if k == "user-agent" || strings.HasPrefix(k, "grpc-") || strings.HasSuffix(k, "-bin") {
+			continue
+		}
+		if req.Metadata == nil {
+			req.Metadata = map[string]string{}
+		}
+		req.Metadata[k] = strings.Join(v, ",")
+	}
+
+	var messages []map[string]any
+	for _, raw := range c.Requests() {
+		var msg map[string]any
+		if err := json.Unmarshal(raw, &msg); err == nil && msg != nil {
+			messages = append(messages, msg)
+		}
+	}
+	if c.ClientStreaming || len(messages) > 1 {
+		req.Messages = messages
+	} else if len(messages) == 1 {
+		req.Body = messages[0]
+	}
+	return req
+}
+
+// Collection returns the calls as a collection of requests.
+func Collection(name string, calls []Call) collection.Collection {
+	c := collection.Collection{Name: name}
+	for _, call := range calls {
+		c.Requests = append(c.Requests, call.Request())
+	}
+	return c
+}
@@ -0,0 +1,370 @@
+// Package proxy forwards gRPC calls to an upstream server unchanged and
+// records them, decoding messages with the upstream's descriptors.
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prnvbn/grpcexp/internal/transcript"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// DefaultLimit is the number of calls kept by default.
+const DefaultLimit = 1000
+
+// Call is a recorded call.
+type Call struct {
+	ID int
+	// Method is the full method name, e.g. helloworld.Greeter.SayHello.
+	Method          string
+	ClientStreaming bool
+	ServerStreaming bool
+	Start           time.Time
+	Duration        time.Duration
+
+	// Metadata is the metadata the client sent.
+	Metadata metadata.MD
+	// Entries are the messages sent each way, the response headers and the
+	// trailers in the order they passed through. Messages are JSON, or a JSON
+	// string holding the base64 encoded message when they can't be decoded.
+	Entries []transcript.Entry
+	// Status is nil while the call is in progress.
+	Status *status.Status
+}
+
+func (c Call) Done() bool {
+	return c.Status != nil
+}
+
+// Requests returns the messages the client sent.
+func (c Call) Requests() []json.RawMessage {
+	return c.messages(transcript.Sent)
+}
+
+// Responses returns the messages the upstream sent.
+func (c Call) Responses() []json.RawMessage {
+	return c.messages(transcript.Received)
+}
+
+func (c Call) messages(direction transcript.Direction) []json.RawMessage {
+	var messages []json.RawMessage
+	for _, entry := range c.Entries {
+		if entry.Direction == direction {
+			messages = append(messages, entry.Message)
+		}
+	}
+	return messages
+}
+
+// MarshalJSON writes a finished call as it is logged.
+func (c Call) MarshalJSON() ([]byte, error) {
+	type entry struct {
+		ID         int                `json:"id"`
+		Method     string             `json:"method"`
+		Start      time.Time          `json:"start"`
+		DurationMs float64            `json:"duration_ms"`
+		Metadata   metadata.MD        `json:"metadata,omitempty"`
+		Status     *transcript.Status `json:"status,omitempty"`
+		Entries    []transcript.Entry `json:"entries"`
+	}
+	e := entry{
+		ID:         c.ID,
+		Method:     c.Method,
+		Start:      c.Start,
+		DurationMs: float64(c.Duration) / float64(time.Millisecond),
+		Metadata:   c.Metadata,
+		Entries:    c.Entries,
+	}
+	if c.Status != nil {
+		e.Status = &transcript.Status{Code: c.Status.Code().String(), Message: c.Status.Message()}
+	}
+	return json.Marshal(e)
+}
+
+// Proxy forwards every call it receives to upstream.
+type Proxy struct {
+	upstream *grpc.ClientConn
+	files    *protoregistry.Files
+	types    *dynamicpb.Types
+
+	mu      sync.Mutex
+	calls   []*Call
+	nextID  int
+	changed chan struct{}
+
+	// Limit is the number of calls kept, the oldest are dropped first.
+	Limit int
+	// OnDone, if set, is called with every finished call. Set it before serving.
+	OnDone func(Call)
+}
+
+// New returns a proxy to upstream that decodes messages with the descriptors
+// in files.
+func New(upstream *grpc.ClientConn, files *protoregistry.Files) *Proxy {
+	return &Proxy{
+		upstream: upstream,
+		files:    files,
+		types:    dynamicpb.NewTypes(files),
+		changed:  make(chan struct{}, 1),
+		Limit:    DefaultLimit,
+	}
+}
+
+// NewServer returns a server that forwards every call to the proxy's upstream.
+func (p *Proxy) NewServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.UnknownServiceHandler(p.forward),
+		grpc.ForceServerCodec(rawCodec{}),
+	)
+	return grpc.NewServer(opts...)
+}
+
+// Calls returns the recorded calls, oldest first.
+func (p *Proxy) Calls() []Call {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	calls := make([]Call, len(p.calls))
+	for i, call := range p.calls {
+		calls[i] = *call
+	}
+	return calls
+}
+
+// Changed receives after calls are recorded or updated.
+func (p *Proxy) Changed() <-chan struct{} {
+	return p.changed
+}
+
+// Clear forgets every recorded call.
+func (p *Proxy) Clear() {
+	p.mu.Lock()
+	p.calls = nil
+	p.mu.Unlock()
+	p.notify()
+}
+
+func (p *Proxy) forward(_ any, ss grpc.ServerStream) error {
+	fullMethod, ok := grpc.MethodFromServerStream(ss)
+	if !ok {
+		return status.Error(codes.Internal, "proxy: no method in stream")
+	}
+	ctx := ss.Context()
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	method := p.findMethod(fullMethod)
+	call := p.start(fullMethod, method, recordedMetadata(md))
+
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(ctx, forwardedMetadata(md)))
+	defer cancel()
+	desc := &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}
+	cs, err := p.upstream.NewStream(ctx, desc, fullMethod, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		p.finish(call, nil, err)
+		return err
+	}
+
+	// forward requests while responses are forwarded below
+	go func() {
+		for {
+			var f frame
+			if err := ss.RecvMsg(&f); err != nil {
+				if errors.Is(err, io.EOF) {
+					_ = cs.CloseSend()
+				} else {
+					cancel()
+				}
+				return
+			}
+			p.recordMessage(call, method, transcript.Sent, f)
+			if err := cs.SendMsg(&f); err != nil {
+				// the upstream's error is returned by RecvMsg
+				return
+			}
+		}
+	}()
+
+	if header, err := cs.Header(); err == nil {
+		p.record(call, transcript.Entry{Direction: transcript.Headers, Metadata: header})
+		if err := ss.SendHeader(header); err != nil {
+			p.finish(call, nil, err)
+			return err
+		}
+	}
+	for {
+		var f frame
+		if err := cs.RecvMsg(&f); err != nil {
+			trailer := cs.Trailer()
+			ss.SetTrailer(trailer)
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			p.finish(call, trailer, err)
+			return err
+		}
+		p.recordMessage(call, method, transcript.Received, f)
+		if err := ss.SendMsg(&f); err != nil {
+			p.finish(call, cs.Trailer(), err)
+			return err
+		}
+	}
+}
+
+// findMethod returns the descriptor of a method named like /pkg.Service/Method,
+// or nil if the descriptors don't have it.
+func (p *Proxy) findMethod(fullMethod string) protoreflect.MethodDescriptor {
+	service, name, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil
+	}
+	d, err := p.files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+	return sd.Methods().ByName(protoreflect.Name(name))
+}
+
+func (p *Proxy) decode(method protoreflect.MethodDescriptor, direction transcript.Direction, f frame) json.RawMessage {
+	if method != nil {
+		md := method.Output()
+		if direction == transcript.Sent {
+			md = method.Input()
+		}
+		msg := dynamicpb.NewMessage(md)
+		if err := proto.Unmarshal(f, msg); err == nil {
+			data, err := protojson.MarshalOptions{Resolver: p.types}.Marshal(msg)
+			var compact bytes.Buffer
+			if err == nil && json.Compact(&compact, data) == nil {
+				return compact.Bytes()
+			}
+		}
+	}
+	raw, _ := json.Marshal(base64.StdEncoding.EncodeToString(f))
+	return raw
+}
+
+// start records a new call. Server reflection calls are forwarded without
+// being recorded, their call is nil.
+func (p *Proxy) start(fullMethod string, method protoreflect.MethodDescriptor, md metadata.MD) *Call {
+	if strings.HasPrefix(fullMethod, "/grpc.reflection.") {
+		return nil
+	}
+	call := &Call{
+		Method:   strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1),
+		Start:    time.Now(),
+		Metadata: md,
+	}
+	if method != nil {
+		call.ClientStreaming = method.IsStreamingClient()
+		call.ServerStreaming = method.IsStreamingServer()
+	}
+
+	p.mu.Lock()
+	p.nextID++
+	call.ID = p.nextID
+	p.calls = append(p.calls, call)
+	if p.Limit > 0 && len(p.calls) > p.Limit {
+		p.calls = p.calls[len(p.calls)-p.Limit:]
+	}
+	p.mu.Unlock()
+
+	p.notify()
+	return call
+}
+
+func (p *Proxy) recordMessage(call *Call, method protoreflect.MethodDescriptor, direction transcript.Direction, f frame) {
+	if call == nil {
+		return
+	}
+	p.record(call, transcript.Entry{Direction: direction, Message: p.decode(method, direction, f)})
+}
+
+func (p *Proxy) record(call *Call, entry transcript.Entry) {
+	if call == nil {
+		return
+	}
+	entry.Time = time.Now()
+	p.mu.Lock()
+	call.Entries = append(call.Entries, entry)
+	p.mu.Unlock()
+	p.notify()
+}
+
+func (p *Proxy) finish(call *Call, trailer metadata.MD, err error) {
+	if call == nil {
+		return
+	}
+	st := status.New(codes.OK, "")
+	if err != nil {
+		st = status.Convert(err)
+	}
+	entry := transcript.Entry{
+		Time:      time.Now(),
+		Direction: transcript.Trailers,
+		Metadata:  trailer,
+		Status:    &transcript.Status{Code: st.Code().String(), Message: st.Message()},
+	}
+
+	p.mu.Lock()
+	call.Entries = append(call.Entries, entry)
+	call.Status = st
+	call.Duration = entry.Time.Sub(call.Start)
+	done := *call
+	p.mu.Unlock()
+
+	p.notify()
+	if p.OnDone != nil {
+		p.OnDone(done)
+	}
+}
+
+func (p *Proxy) notify() {
+	select {
+	case p.changed <- struct{}{}:
+	default:
+	}
+}
+
+// recordedMetadata drops the transport headers from metadata a client sent.
+func recordedMetadata(md metadata.MD) metadata.MD {
+	out := metadata.MD{}
+	for k, v := range md {
+		if strings.HasPrefix(k, ":") || k == "content-type" {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// forwardedMetadata is the metadata passed upstream. gRPC sets its own
+// grpc- headers and user agent.
+func forwardedMetadata(md metadata.MD) metadata.MD {
+	out := recordedMetadata(md)
+	for k := range out {
+		if strings.HasPrefix(k, "grpc-") {
+			delete(out, k)
+		}
+	}
+	return out
+}
@@ -0,0 +1,233 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	featurev1 "github.com/prnvbn/grpcexp/cmd/testserver/feature"
+	hellov1 "github.com/prnvbn/grpcexp/cmd/testserver/hello"
+	"github.com/prnvbn/grpcexp/cmd/testserver/server"
+	grpcclient "github.com/prnvbn/grpcexp/internal/grpc"
+	"github.com/prnvbn/grpcexp/internal/transcript"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// newTestProxy proxies the test server and returns a connection to the proxy.
+func newTestProxy(t *testing.T) (*Proxy, *grpc.ClientConn) {
+	t.Helper()
+
+	p := New(server.Dial(t), protoregistry.GlobalFiles)
+	return p, server.Serve(t, p.NewServer())
+}
+
+func TestUnary(t *testing.T) {
+	p, cc := newTestProxy(t)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-user", "pranav")
+	reply, err := helloworldpb.NewGreeterClient(cc).SayHello(ctx, &helloworldpb.HelloRequest{Name: "proxy"})
+	if err != nil || reply.GetMessage() != "Hello proxy" {
+		t.Fatalf("SayHello = %v, %v, want Hello proxy", reply, err)
+	}
+
+	calls := p.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("recorded %d calls, want 1", len(calls))
+	}
+	call := calls[0]
+	if call.Method != "helloworld.Greeter.SayHello" || !call.Done() || call.Status.Code() != codes.OK {
+		t.Fatalf("recorded %s with status %v", call.Method, call.Status)
+	}
+	if got := string(call.Requests()[0]); got != `{"name":"proxy"}` {
+		t.Errorf("request = %s", got)
+	}
+	if got := string(call.Responses()[0]); got != `{"message":"Hello proxy"}` {
+		t.Errorf("response = %s", got)
+	}
+
+	req := call.Request()
+	if req.Body["name"] != "proxy" || req.Metadata["x-user"] != "pranav" || req.Messages != nil {
+		t.Errorf("Request() = %+v", req)
+	}
+	if _, ok := req.Metadata["user-agent"]; ok {
+		t.Errorf("Request() kept the user agent: %v", req.Metadata)
+	}
+}
+
+func TestMetadataAndErrors(t *testing.T) {
+	p, cc := newTestProxy(t)
+	client := featurev1.NewFeatureServiceClient(cc)
+
+	var header, trailer metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "42")
+	reply, err := client.Metadata(ctx, &featurev1.MetadataRequest{
+		Headers:  map[string]string{"x-header": "h"},
+		Trailers: map[string]string{"x-trailer": "t"},
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	if err != nil {
+		t.Fatalf("Metadata returned error: %v", err)
+	}
+	if reply.GetReceived()["x-request-id"] != "42" {
+		t.Errorf("upstream received %v, want x-request-id", reply.GetReceived())
+	}
+	if header.Get("x-header")[0] != "h" || trailer.Get("x-trailer")[0] != "t" {
+		t.Errorf("client got header %v and trailer %v", header, trailer)
+	}
+
+	_, err = client.Fail(context.Background(), &featurev1.FailRequest{Code: int32(codes.NotFound), Message: "gone"})
+	st := status.Convert(err)
+	if st.Code() != codes.NotFound || st.Message() != "gone" || len(st.Details()) != 4 {
+		t.Fatalf("Fail error = %v with %d details, want NotFound with 4", err, len(st.Details()))
+	}
+
+	calls := p.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("recorded %d calls, want 2", len(calls))
+	}
+	var directions []transcript.Direction
+	for _, entry := range calls[0].Entries {
+		directions = append(directions, entry.Direction)
+	}
+	want := []transcript.Direction{transcript.Sent, transcript.Headers, transcript.Received, transcript.Trailers}
+	if !slices.Equal(directions, want) {
+		t.Fatalf("recorded %v, want %v", directions, want)
+	}
+	if calls[0].Entries[1].Metadata["x-header"][0] != "h" || calls[0].Entries[3].Metadata["x-trailer"][0] != "t" {
+		t.Errorf("recorded header %v and trailer %v", calls[0].Entries[1].Metadata, calls[0].Entries[3].Metadata)
+	}
+	if calls[1].Status.Code() != codes.NotFound || len(calls[1].Status.Details()) != 4 {
+		t.Errorf("recorded status %v", calls[1].Status)
+	}
+}
+
+func TestBidiStream(t *testing.T) {
+	p, cc := newTestProxy(t)
+
+	stream, err := hellov1.NewHelloServiceClient(cc).HelloStream(context.Background())
+	if err != nil {
+		t.Fatalf("HelloStream returned error: %v", err)
+	}
+	for _, name := range []string{"a", "b"} {
+		if err := stream.Send(&hellov1.HelloRequest{Name: name}); err != nil {
+			t.Fatalf("Send returned error: %v", err)
+		}
+		reply, err := stream.Recv()
+		if err != nil || reply.GetMessage() != "Hello "+name {
+			t.Fatalf("Recv = %v, %v, want Hello %s", reply, err, name)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend returned error: %v", err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("Recv after CloseSend = %v, want EOF", err)
+	}
+
+	calls := p.Calls()
+	if len(calls) != 1 || len(calls[0].Requests()) != 2 || len(calls[0].Responses()) != 2 {
+		t.Fatalf("recorded %+v, want one call with two messages each way", calls)
+	}
+	req := calls[0].Request()
+	if len(req.Messages) != 2 || req.Messages[1]["name"] != "b" || req.Body != nil {
+		t.Errorf("Request() = %+v", req)
+	}
+}
+
+func TestUndecodedMessages(t *testing.T) {
+	// without descriptors messages are kept as base64
+	p := New(server.Dial(t), new(protoregistry.Files))
+	cc := server.Serve(t, p.NewServer())
+
+	if _, err := helloworldpb.NewGreeterClient(cc).SayHello(context.Background(), &helloworldpb.HelloRequest{Name: "x"}); err != nil {
+		t.Fatalf("SayHello returned error: %v", err)
+	}
+	call := p.Calls()[0]
+	if got := string(call.Requests()[0]); got != `"CgF4"` {
+		t.Errorf("request = %s, want base64", got)
+	}
+	if req := call.Request(); req.Body != nil || req.Messages != nil {
+		t.Errorf("Request() = %+v, want no messages", req)
+	}
+}
+
+func TestReflectionIsNotRecorded(t *testing.T) {
+	p, cc := newTestProxy(t)
+
+	stream, err := grpc_reflection_v1.NewServerReflectionClient(cc).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("ServerReflectionInfo returned error: %v", err)
+	}
+	if err := stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv returned error: %v", err)
+	}
+	_ = stream.CloseSend()
+
+	if calls := p.Calls(); len(calls) != 0 {
+		t.Fatalf("recorded %d calls, want reflection to be forwarded only", len(calls))
+	}
+}
+
+func TestAuthorizationIsForwarded(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		seen [][]string
+	)
+	dialer := server.Start(t, grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		mu.Lock()
+		seen = append(seen, md.Get("authorization"))
+		mu.Unlock()
+		return handler(ctx, req)
+	}))
+
+	// the proxy's own token must not reach the server with forwarded calls
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := grpcclient.NewClient(ctx, grpcclient.Config{
+		Target: "bufnet",
+		Dialer: dialer,
+		Creds:  insecure.NewCredentials(),
+		Auth:   &grpcclient.StaticToken{Value: "operator"},
+	})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	defer client.Close() //nolint:errcheck // test client
+	upstream, err := client.ForwardConn(ctx)
+	if err != nil {
+		t.Fatalf("ForwardConn returned error: %v", err)
+	}
+	defer upstream.Close() //nolint:errcheck // test conn
+	cc := server.Serve(t, New(upstream, protoregistry.GlobalFiles).NewServer())
+
+	greeter := helloworldpb.NewGreeterClient(cc)
+	authorized := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer caller")
+	for _, ctx := range []context.Context{authorized, context.Background()} {
+		if _, err := greeter.SayHello(ctx, &helloworldpb.HelloRequest{Name: "auth"}); err != nil {
+			t.Fatalf("SayHello returned error: %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := [][]string{{"Bearer caller"}, nil}
+	if !reflect.DeepEqual(seen, want) {
+		t.Fatalf("upstream saw authorization %q, want %q", seen, want)
+	}
+}
@@ -0,0 +1,181 @@
+package suite
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Report holds the results of a suite.
+type Report struct {
+	Name    string
+	Results []Result
+}
+
+// Failed counts the cases that failed.
+func (r Report) Failed() int {
+	failed := 0
+	for _, result := range r.Results {
+		if !result.Passed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+func (r Report) duration() time.Duration {
+	var total time.Duration
+	for _, result := range r.Results {
+		total += result.Duration
+	}
+	return total
+}
+
+// WriteText writes a line per case with its failures indented below it.
+func WriteText(w io.Writer, reports []Report) error {
+	var out strings.Builder
+	total, failed := 0, 0
+	for _, report := range reports {
+		for _, result := range report.Results {
+			mark := "PASS"
+			if !result.Passed() {
+				mark = "FAIL"
+			}
+			fmt.Fprintf(&out, "%s %s: %s (%s)\n", mark, report.Name, result.Name, result.Duration.Round(time.Microsecond))
+			for _, failure := range result.Failures {
+				fmt.Fprintf(&out, "    %s\n", failure)
+			}
+		}
+		total += len(report.Results)
+		failed += report.Failed()
+	}
+	fmt.Fprintf(&out, "%d passed, %d failed\n", total-failed, failed)
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// WriteTAP writes the results as TAP version 13, with the failures of a case
+// in its YAML diagnostic block.
+func WriteTAP(w io.Writer, reports []Report) error {
+	var out strings.Builder
+	total := 0
+	for _, report := range reports {
+		total += len(report.Results)
+	}
+	fmt.Fprintf(&out, "TAP version 13\n1..%d\n", total)
+
+	n := 0
+	for _, report := range reports {
+		for _, result := range report.Results {
+			n++
+			status := "ok"
+			if !result.Passed() {
+				status = "not ok"
+			}
+			fmt.Fprintf(&out, "%s %d - %s: %s\n", status, n, tapEscape(report.Name), tapEscape(result.Name))
+			if result.Passed() {
+				continue
+			}
+
+			var diagnostic strings.Builder
+			enc := yaml.NewEncoder(&diagnostic)
+			enc.SetIndent(2)
+			err := enc.Encode(map[string]any{
+				"method":      result.Method,
+				"duration_ms": float64(result.Duration) / float64(time.Millisecond),
+				"failures":    result.Failures,
+			})
+			if err != nil {
+				return err
+			}
+			out.WriteString("  ---\n")
+			for _, line := range strings.Split(strings.TrimSuffix(diagnostic.String(), "\n"), "\n") {
+				out.WriteString("  " + line + "\n")
+			}
+			out.WriteString("  ...\n")
+		}
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// tapEscape keeps a description from being read as a directive.
+func tapEscape(s string) string {
+	return strings.ReplaceAll(s, "#", `\#`)
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the results as JUnit XML, a testsuite per report.
+func WriteJUnit(w io.Writer, reports []Report) error {
+	doc := junitSuites{}
+	var total time.Duration
+	for _, report := range reports {
+		suite := junitSuite{
+			Name:     report.Name,
+			Tests:    len(report.Results),
+			Failures: report.Failed(),
+			Time:     seconds(report.duration()),
+		}
+		for _, result := range report.Results {
+			c := junitCase{Name: result.Name, Classname: result.Method, Time: seconds(result.Duration)}
+			if !result.Passed() {
+				c.Failure = &junitFailure{
+					Message: result.Failures[0],
+					Text:    strings.Join(result.Failures, "\n"),
+				}
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+		doc.Suites = append(doc.Suites, suite)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		total += report.duration()
+	}
+	doc.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
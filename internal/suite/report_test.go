@@ -0,0 +1,88 @@
+package suite
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var testReports = []Report{{
+	Name: "greeter",
+	Results: []Result{
+		{Name: "greets", Method: "helloworld.Greeter.SayHello", Duration: 1500 * time.Microsecond},
+		{Name: "fails #1", Method: "helloworld.Greeter.SayHello", Duration: 2 * time.Millisecond, Failures: []string{
+			"status: got NotFound, want OK",
+			`message: "a" doesn't match "<b>"`,
+		}},
+	},
+}}
+
+func TestWriteTAP(t *testing.T) {
+	var out strings.Builder
+	if err := WriteTAP(&out, testReports); err != nil {
+		t.Fatalf("WriteTAP returned error: %v", err)
+	}
+
+	want := `TAP version 13
+1..2
+ok 1 - greeter: greets
+not ok 2 - greeter: fails \#1
+  ---
+  duration_ms: 2
+  failures:
+    - 'status: got NotFound, want OK'
+    - 'message: "a" doesn''t match "<b>"'
+  method: helloworld.Greeter.SayHello
+  ...
+`
+	if out.String() != want {
+		t.Errorf("WriteTAP wrote\n%s\nwant\n%s", out.String(), want)
+	}
+
+	// suite names are descriptions too
+	out.Reset()
+	if err := WriteTAP(&out, []Report{{Name: "smoke #2", Results: []Result{{Name: "pings"}}}}); err != nil {
+		t.Fatalf("WriteTAP returned error: %v", err)
+	}
+	if want := "ok 1 - smoke \\#2: pings\n"; !strings.Contains(out.String(), want) {
+		t.Errorf("WriteTAP wrote\n%s\nwant a line %q", out.String(), want)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var out strings.Builder
+	if err := WriteJUnit(&out, testReports); err != nil {
+		t.Fatalf("WriteJUnit returned error: %v", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="2" failures="1" time="0.004">
+  <testsuite name="greeter" tests="2" failures="1" time="0.004">
+    <testcase name="greets" classname="helloworld.Greeter.SayHello" time="0.002"></testcase>
+    <testcase name="fails #1" classname="helloworld.Greeter.SayHello" time="0.002">
+      <failure message="status: got NotFound, want OK">status: got NotFound, want OK&#xA;message: &#34;a&#34; doesn&#39;t match &#34;&lt;b&gt;&#34;</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if out.String() != want {
+		t.Errorf("WriteJUnit wrote\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var out strings.Builder
+	if err := WriteText(&out, testReports); err != nil {
+		t.Fatalf("WriteText returned error: %v", err)
+	}
+
+	want := `PASS greeter: greets (1.5ms)
+FAIL greeter: fails #1 (2ms)
+    status: got NotFound, want OK
+    message: "a" doesn't match "<b>"
+1 passed, 1 failed
+`
+	if out.String() != want {
+		t.Errorf("WriteText wrote\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,376 @@
+// Package suite runs test files: collections whose requests carry the status
+// and response they are expected to get.
+package suite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/prnvbn/grpcexp/internal/collection"
+	"github.com/prnvbn/grpcexp/internal/jsonpath"
+	"github.com/prnvbn/grpcexp/internal/placeholder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// Suite is a test file. A collection is a valid suite whose requests are all
+// expected to succeed.
+type Suite struct {
+	Name string `yaml:"name,omitempty"`
+	// Vars are the variables the requests start with.
+	Vars  map[string]any `yaml:"vars,omitempty"`
+	Cases []Case         `yaml:"requests"`
+}
+
+// Case is a request and what it is expected to get back.
+type Case struct {
+	collection.Request `yaml:",inline"`
+	Expect             Expect `yaml:"expect,omitempty"`
+}
+
+type Expect struct {
+	// Status is the expected status code, OK when unset.
+	Status Code `yaml:"status,omitempty"`
+	// Message is a regular expression the status message must match.
+	Message string `yaml:"message,omitempty"`
+	// Response must equal the whole response once Ignore is applied.
+	Response map[string]any `yaml:"response,omitempty"`
+	// Ignore lists paths, such as $.createTime, left out of the Response
+	// comparison.
+	Ignore []string `yaml:"ignore,omitempty"`
+	// Paths maps paths in the response to the values they must equal.
+	Paths map[string]any `yaml:"paths,omitempty"`
+	// Regex maps paths in the response to regular expressions their values
+	// must match.
+	Regex map[string]string `yaml:"regex,omitempty"`
+}
+
+// Code reads a status code by name, as "NotFound" or "NOT_FOUND", or by number.
+type Code codes.Code
+
+func (c *Code) UnmarshalYAML(node *yaml.Node) error {
+	if n, err := strconv.ParseUint(node.Value, 10, 32); err == nil {
+		*c = Code(n)
+		return nil
+	}
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if code.String() == node.Value {
+			*c = Code(code)
+			return nil
+		}
+	}
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(strconv.Quote(node.Value))); err != nil {
+		return fmt.Errorf("line %d: unknown status code %q", node.Line, node.Value)
+	}
+	*c = Code(code)
+	return nil
+}
+
+func (c Code) MarshalYAML() (any, error) {
+	return codes.Code(c).String(), nil
+}
+
+// Read reads a suite.
+func Read(r io.Reader) (Suite, error) {
+	var s Suite
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil && err != io.EOF {
+		return Suite{}, fmt.Errorf("failed to read test file: %w", err)
+	}
+	for i, c := range s.Cases {
+		if err := c.Validate(); err != nil {
+			return Suite{}, fmt.Errorf("failed to read test file: request %d: %w", i+1, err)
+		}
+		if err := c.Expect.validate(); err != nil {
+			return Suite{}, fmt.Errorf("failed to read test file: request %d: %w", i+1, err)
+		}
+	}
+	return s, nil
+}
+
+// Load reads the suite in the file at path.
+func Load(path string) (Suite, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Suite{}, err
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+	return Read(f)
+}
+
+func (e Expect) validate() error {
+	if _, err := regexp.Compile(e.Message); err != nil {
+		return fmt.Errorf("invalid message pattern: %w", err)
+	}
+	for _, path := range e.Ignore {
+		if _, err := jsonpath.Parse(path); err != nil {
+			return err
+		}
+	}
+	for path := range e.Paths {
+		if _, err := jsonpath.Parse(path); err != nil {
+			return err
+		}
+	}
+	for path, pattern := range e.Regex {
+		if _, err := jsonpath.Parse(path); err != nil {
+			return err
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid pattern for %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Invoker calls a unary method with request as JSON and returns the response
+// as JSON, sending the outgoing metadata in ctx. Client.InvokeRPC is one.
+type Invoker func(ctx context.Context, method string, request map[string]any) (string, error)
+
+// Result is the outcome of one case.
+type Result struct {
+	Name     string
+	Method   string
+	Duration time.Duration
+	// Failures describes each expectation that wasn't met.
+	Failures []string
+}
+
+func (r Result) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// Run runs the cases of s in order, giving each call at most timeout when it
+// isn't 0. Values captured from a response are available to the requests and
+// expectations of the cases after it.
+func Run(ctx context.Context, invoke Invoker, s Suite, timeout time.Duration) Report {
+	report := Report{Name: s.Name, Results: make([]Result, 0, len(s.Cases))}
+	vars := collection.Vars{}
+	maps.Copy(vars, s.Vars)
+	for i, c := range s.Cases {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprintf("%d %s", i+1, c.Method)
+		}
+		report.Results = append(report.Results, runCase(ctx, invoke, c.expand(vars, i+1), name, timeout, vars))
+	}
+	return report
+}
+
+// expand resolves the placeholders in the request and in the values it
+// expects.
+func (c Case) expand(vars collection.Vars, seq int) Case {
+	c.Request = c.Request.Expand(vars, seq)
+	ctx := placeholder.Context{Seq: seq, Vars: vars}
+	if c.Expect.Response != nil {
+		c.Expect.Response = placeholder.Expand(c.Expect.Response, ctx)
+	}
+	if c.Expect.Paths != nil {
+		c.Expect.Paths = placeholder.Expand(c.Expect.Paths, ctx)
+	}
+	return c
+}
+
+func runCase(ctx context.Context, invoke Invoker, c Case, name string, timeout time.Duration, vars collection.Vars) Result {
+	result := Result{Name: name, Method: c.Method}
+	if len(c.Messages) > 0 {
+		result.Failures = append(result.Failures, "streaming requests are not supported, use body")
+		return result
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	ctx = metadata.NewOutgoingContext(ctx, metadata.New(c.Metadata))
+	body := c.Body
+	if body == nil {
+		body = map[string]any{}
+	}
+
+	start := time.Now()
+	response, err := invoke(ctx, c.Method, body)
+	result.Duration = time.Since(start)
+
+	st := status.New(codes.OK, "")
+	if err != nil {
+		var ok bool
+		if st, ok = status.FromError(err); !ok {
+			// not a status of the call itself, such as an unknown method
+			result.Failures = append(result.Failures, err.Error())
+			return result
+		}
+	}
+	result.Failures = c.Expect.check(st, response)
+	if st.Code() == codes.OK {
+		if err := c.CaptureVars(response, vars); err != nil {
+			result.Failures = append(result.Failures, err.Error())
+		}
+	}
+	return result
+}
+
+// check compares the outcome of a call with the expectations.
+func (e Expect) check(st *status.Status, response string) []string {
+	var failures []string
+	if st.Code() != codes.Code(e.Status) {
+		failure := fmt.Sprintf("status: got %s, want %s", st.Code(), codes.Code(e.Status))
+		if st.Message() != "" {
+			failure += fmt.Sprintf(" (%s)", st.Message())
+		}
+		return append(failures, failure)
+	}
+	if e.Message != "" && !regexp.MustCompile(e.Message).MatchString(st.Message()) {
+		failures = append(failures, fmt.Sprintf("message: %q doesn't match %q", st.Message(), e.Message))
+	}
+	if st.Code() != codes.OK {
+		// there is no response to check
+		return failures
+	}
+
+	var got any
+	if err := json.Unmarshal([]byte(response), &got); err != nil {
+		return append(failures, fmt.Sprintf("response is not JSON: %v", err))
+	}
+
+	if e.Response != nil {
+		want := normalize(e.Response)
+		got := normalize(got)
+		for _, path := range e.Ignore {
+			p, _ := jsonpath.Parse(path)
+			p.Delete(want)
+			p.Delete(got)
+		}
+		if diff := difference("$", want, got); diff != "" {
+			failures = append(failures, "response: "+diff)
+		}
+	}
+	for _, path := range sortedKeys(e.Paths) {
+		p, _ := jsonpath.Parse(path)
+		value, ok := p.Get(got)
+		if !ok {
+			failures = append(failures, fmt.Sprintf("%s: not found", path))
+			continue
+		}
+		if diff := difference(path, normalize(e.Paths[path]), value); diff != "" {
+			failures = append(failures, diff)
+		}
+	}
+	for _, path := range sortedKeys(e.Regex) {
+		p, _ := jsonpath.Parse(path)
+		value, ok := p.Get(got)
+		if !ok {
+			failures = append(failures, fmt.Sprintf("%s: not found", path))
+			continue
+		}
+		text := scalarText(value)
+		if !regexp.MustCompile(e.Regex[path]).MatchString(text) {
+			failures = append(failures, fmt.Sprintf("%s: %q doesn't match %q", path, text, e.Regex[path]))
+		}
+	}
+	return failures
+}
+
+// normalize round trips v through JSON so values read from YAML compare like
+// the decoded response.
+func normalize(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// difference describes the first difference between want and got at path, or
+// returns "" when they are equal. Scalars are compared by their text so 5
+// equals an int64 field, which JSON encodes as "5".
+func difference(path string, want, got any) string {
+	switch want := want.(type) {
+	case map[string]any:
+		gotMap, ok := got.(map[string]any)
+		if !ok {
+			return fmt.Sprintf("%s: got %s, want an object", path, jsonText(got))
+		}
+		for _, key := range sortedKeys(want) {
+			gotValue, ok := gotMap[key]
+			if !ok {
+				return fmt.Sprintf("%s.%s: missing", path, key)
+			}
+			if diff := difference(path+"."+key, want[key], gotValue); diff != "" {
+				return diff
+			}
+		}
+		for _, key := range sortedKeys(gotMap) {
+			if _, ok := want[key]; !ok {
+				return fmt.Sprintf("%s.%s: unexpected %s", path, key, jsonText(gotMap[key]))
+			}
+		}
+		return ""
+	case []any:
+		gotList, ok := got.([]any)
+		if !ok {
+			return fmt.Sprintf("%s: got %s, want a list", path, jsonText(got))
+		}
+		if len(gotList) != len(want) {
+			return fmt.Sprintf("%s: got %d elements, want %d", path, len(gotList), len(want))
+		}
+		for i := range want {
+			if diff := difference(fmt.Sprintf("%s[%d]", path, i), want[i], gotList[i]); diff != "" {
+				return diff
+			}
+		}
+		return ""
+	default:
+		if _, isMap := got.(map[string]any); isMap || isList(got) || scalarText(want) != scalarText(got) {
+			return fmt.Sprintf("%s: got %s, want %s", path, jsonText(got), jsonText(want))
+		}
+		return ""
+	}
+}
+
+func isList(v any) bool {
+	_, ok := v.([]any)
+	return ok
+}
+
+func scalarText(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return jsonText(v)
+}
+
+func jsonText(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// sortedKeys keeps failures in a stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,176 @@
+package suite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prnvbn/grpcexp/cmd/testserver/server"
+	"github.com/prnvbn/grpcexp/internal/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func newTestClient(t *testing.T) *grpc.Client {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := grpc.NewClient(ctx, grpc.Config{
+		Target: "bufnet",
+		Dialer: server.Start(t),
+		Creds:  insecure.NewCredentials(),
+	})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+const testFile = `
+name: features
+requests:
+  - name: greets
+    method: helloworld.Greeter.SayHello
+    body: {name: suite}
+    expect:
+      response: {message: Hello suite}
+  - name: echoes metadata
+    method: feature.v1.FeatureService.Metadata
+    metadata: {x-request-id: "42"}
+    expect:
+      paths:
+        $.received.x-request-id: "42"
+      regex:
+        $.received.user-agent: grpc-go
+      ignore: [$.received]
+      response: {}
+  - name: fails
+    method: feature.v1.FeatureService.Fail
+    body: {code: 5, message: gone}
+    expect:
+      status: NOT_FOUND
+      message: ^go
+  - name: wrong response
+    method: helloworld.Greeter.SayHello
+    body: {name: x}
+    expect:
+      response: {message: Hello y}
+      paths:
+        $.missing: 1
+  - name: wrong status
+    method: feature.v1.FeatureService.Fail
+    expect:
+      status: OK
+  - method: feature.v1.FeatureService.Nope
+`
+
+func TestRun(t *testing.T) {
+	s, err := Read(strings.NewReader(testFile))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	report := Run(context.Background(), newTestClient(t).InvokeRPC, s, 5*time.Second)
+	if report.Name != "features" || len(report.Results) != 6 {
+		t.Fatalf("Run = %+v, want 6 results for features", report)
+	}
+
+	want := []struct {
+		name     string
+		failures []string
+	}{
+		{name: "greets"},
+		{name: "echoes metadata"},
+		{name: "fails"},
+		{name: "wrong response", failures: []string{`response: $.message: got "Hello x", want "Hello y"`, "$.missing: not found"}},
+		{name: "wrong status", failures: []string{"status: got InvalidArgument, want OK (request failed on purpose)"}},
+		{name: "6 feature.v1.FeatureService.Nope", failures: []string{"RPC invocation failed"}},
+	}
+	for i, w := range want {
+		got := report.Results[i]
+		if got.Name != w.name || len(got.Failures) != len(w.failures) {
+			t.Errorf("result %d = %s with failures %q, want %s with %q", i+1, got.Name, got.Failures, w.name, w.failures)
+			continue
+		}
+		for j := range w.failures {
+			if !strings.HasPrefix(got.Failures[j], w.failures[j]) {
+				t.Errorf("%s failure %d = %q, want %q", w.name, j+1, got.Failures[j], w.failures[j])
+			}
+		}
+	}
+	if report.Failed() != 3 {
+		t.Errorf("Failed() = %d, want 3", report.Failed())
+	}
+}
+
+func TestDifference(t *testing.T) {
+	tests := []struct {
+		want, got any
+		diff      string
+	}{
+		{want: map[string]any{"id": float64(5)}, got: map[string]any{"id": "5"}},
+		{want: map[string]any{"a": []any{"x"}}, got: map[string]any{"a": []any{"x", "y"}}, diff: "$.a: got 2 elements, want 1"},
+		{want: map[string]any{}, got: map[string]any{"extra": true}, diff: "$.extra: unexpected true"},
+		{want: map[string]any{"a": map[string]any{"b": "c"}}, got: map[string]any{"a": "c"}, diff: `$.a: got "c", want an object`},
+		{want: map[string]any{"a": "1"}, got: map[string]any{"a": map[string]any{}}, diff: `$.a: got {}, want "1"`},
+	}
+	for _, tt := range tests {
+		if diff := difference("$", tt.want, tt.got); diff != tt.diff {
+			t.Errorf("difference(%v, %v) = %q, want %q", tt.want, tt.got, diff, tt.diff)
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for name, file := range map[string]string{
+		"no method":      "requests: [{body: {}}]",
+		"unknown status": "requests: [{method: a.B.C, expect: {status: Broken}}]",
+		"bad path":       "requests: [{method: a.B.C, expect: {paths: {'$.a[': 1}}}]",
+		"bad regex":      "requests: [{method: a.B.C, expect: {regex: {$.a: '('}}}]",
+		"unknown field":  "requests: [{method: a.B.C, expect: {nope: 1}}]",
+	} {
+		if _, err := Read(strings.NewReader(file)); err == nil {
+			t.Errorf("Read with %s returned no error", name)
+		}
+	}
+}
+
+func TestRunChained(t *testing.T) {
+	s, err := Read(strings.NewReader(`
+vars: {user: pranav}
+requests:
+  - name: create
+    method: helloworld.Greeter.SayHello
+    body: {name: "{{user}}"}
+    capture: {greeting: $.message}
+  - name: uses body
+    method: helloworld.Greeter.SayHello
+    body: {name: "{{greeting}}"}
+    expect:
+      response: {message: "Hello {{greeting}}"}
+  - name: uses metadata
+    method: feature.v1.FeatureService.Metadata
+    metadata: {x-greeting: "{{greeting}}"}
+    expect:
+      paths: {$.received.x-greeting: "{{greeting}}"}
+  - name: missing capture
+    method: helloworld.Greeter.SayHello
+    capture: {id: $.id}
+`))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	report := Run(context.Background(), newTestClient(t).InvokeRPC, s, 5*time.Second)
+	for _, result := range report.Results[:3] {
+		if !result.Passed() {
+			t.Errorf("%s failed: %q", result.Name, result.Failures)
+		}
+	}
+	last := report.Results[3]
+	if len(last.Failures) != 1 || last.Failures[0] != "failed to capture id: $.id not found in response" {
+		t.Errorf("missing capture failures = %q", last.Failures)
+	}
+}
@@ -0,0 +1,151 @@
+// Package transcript reads and writes stream transcripts as NDJSON or as a
+// HAR-like JSON document.
+package transcript
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Direction says what a transcript entry records.
+type Direction string
+
+const (
+	Sent Direction = "sent"
+	// SentHeaders entries record the request metadata the call started with.
+	SentHeaders Direction = "sent-headers"
+	Received    Direction = "received"
+	Headers     Direction = "headers"
+	Trailers    Direction = "trailers"
+	Error       Direction = "error"
+	// Info entries record local events such as the stream closing or being reset.
+	Info Direction = "info"
+)
+
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatNDJSON
+)
+
+// FormatForPath picks NDJSON for .ndjson and .jsonl files and JSON otherwise.
+func FormatForPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	default:
+		return FormatJSON
+	}
+}
+
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Direction Direction `json:"direction"`
+	// Message is the JSON payload of sent and received messages.
+	Message  json.RawMessage     `json:"message,omitempty"`
+	Metadata map[string][]string `json:"metadata,omitempty"`
+	Status   *Status             `json:"status,omitempty"`
+	// Text describes error and info entries.
+	Text string `json:"text,omitempty"`
+}
+
+type Status struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// Log is a recorded stream.
+type Log struct {
+	Method  string  `json:"method,omitempty"`
+	Target  string  `json:"target,omitempty"`
+	Entries []Entry `json:"entries"`
+}
+
+// document is the HAR-like envelope of the JSON format.
+type document struct {
+	Log *documentLog `json:"log"`
+}
+
+type documentLog struct {
+	Version string  `json:"version"`
+	Creator creator `json:"creator"`
+	Log
+}
+
+type creator struct {
+	Name string `json:"name"`
+}
+
+const documentVersion = "1.0"
+
+// Write writes log to w in the given format. NDJSON output has one entry per
+// line and omits the method and target.
+func Write(w io.Writer, format Format, log Log) error {
+	if format == FormatNDJSON {
+		enc := json.NewEncoder(w)
+		for _, entry := range log.Entries {
+			if err := enc.Encode(entry); err != nil {
+				return fmt.Errorf("failed to write transcript: %w", err)
+			}
+		}
+		return nil
+	}
+
+	doc := document{Log: &documentLog{
+		Version: documentVersion,
+		Creator: creator{Name: "grpcexp"},
+		Log:     log,
+	}}
+	if doc.Log.Entries == nil {
+		doc.Log.Entries = []Entry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+	return nil
+}
+
+// Read reads a transcript written in either format.
+func Read(r io.Reader) (Log, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Log{}, fmt.Errorf("failed to read transcript: %w", err)
+	}
+
+	var doc document
+	if err := json.Unmarshal(data, &doc); err == nil && doc.Log != nil {
+		return doc.Log.Log, nil
+	}
+
+	var log Log
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(text, &entry); err != nil {
+			return Log{}, fmt.Errorf("failed to read transcript: line %d: %w", line, err)
+		}
+		if entry.Direction == "" {
+			return Log{}, fmt.Errorf("failed to read transcript: line %d: missing direction", line)
+		}
+		log.Entries = append(log.Entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return Log{}, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	return log, nil
+}
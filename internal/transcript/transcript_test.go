@@ -0,0 +1,60 @@
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	log := Log{
+		Method: "hello.v1.HelloService.HelloStream",
+		Target: "localhost:50051",
+		Entries: []Entry{
+			{Time: at, Direction: Sent, Message: json.RawMessage(`{"name":"a"}`)},
+			{Time: at, Direction: Headers, Metadata: map[string][]string{"content-type": {"application/grpc"}}},
+			{Time: at, Direction: Received, Message: json.RawMessage(`{"message":"Hello a"}`)},
+			{Time: at, Direction: Trailers, Status: &Status{Code: "OK"}},
+			{Time: at, Direction: Info, Text: "closed"},
+		},
+	}
+
+	for _, format := range []Format{FormatJSON, FormatNDJSON} {
+		var buf bytes.Buffer
+		if err := Write(&buf, format, log); err != nil {
+			t.Fatalf("Write(%d) returned error: %v", format, err)
+		}
+
+		got, err := Read(&buf)
+		if err != nil {
+			t.Fatalf("Read(%d) returned error: %v", format, err)
+		}
+		if len(got.Entries) != len(log.Entries) {
+			t.Fatalf("Read(%d) returned %d entries, want %d", format, len(got.Entries), len(log.Entries))
+		}
+		if format == FormatJSON && (got.Method != log.Method || got.Target != log.Target) {
+			t.Errorf("Read(JSON) = %q %q, want %q %q", got.Method, got.Target, log.Method, log.Target)
+		}
+		var message bytes.Buffer
+		if err := json.Compact(&message, got.Entries[2].Message); err != nil || message.String() != `{"message":"Hello a"}` {
+			t.Errorf("Read(%d) message = %s", format, got.Entries[2].Message)
+		}
+		if got.Entries[3].Status == nil || got.Entries[3].Status.Code != "OK" {
+			t.Errorf("Read(%d) status = %v, want OK", format, got.Entries[3].Status)
+		}
+		if !got.Entries[0].Time.Equal(at) {
+			t.Errorf("Read(%d) time = %v, want %v", format, got.Entries[0].Time, at)
+		}
+	}
+}
+
+func TestFormatForPath(t *testing.T) {
+	if FormatForPath("out.ndjson") != FormatNDJSON || FormatForPath("out.JSONL") != FormatNDJSON {
+		t.Error("FormatForPath did not pick NDJSON for .ndjson and .jsonl")
+	}
+	if FormatForPath("out.json") != FormatJSON {
+		t.Error("FormatForPath did not pick JSON for .json")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/placeholder"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -12,11 +13,17 @@ type Builder struct {
 	root              *fieldGroup
 	submitFocused     bool
 	unsupportedFields []string
+	// unfilled are the paths of request values Fill had no field for
+	unfilled []string
+	// vars fill the placeholders of the payload along with the generators
+	vars map[string]any
 }
 
 func NewBuilder(msgDesc protoreflect.MessageDescriptor) *Builder {
+	root, unsupported := buildFieldGroup(msgDesc)
 	b := &Builder{
-		root: buildFieldGroup(msgDesc),
+		root:              root,
+		unsupportedFields: unsupported,
 	}
 
 	if b.root.Empty() {
@@ -34,6 +41,10 @@ func (b *Builder) HandleKey(msg tea.KeyMsg, onSubmit func() tea.Cmd) (tea.Cmd, b
 		b.nextField()
 		return nil, true
 	case "enter", " ":
+		if msg.String() == " " && !b.submitFocused && b.root.AcceptsTextInput() {
+			// typed into the text field, e.g. {{randInt 1 100}}
+			return nil, false
+		}
 		if b.submitFocused {
 			return onSubmit(), true
 		}
@@ -57,7 +68,9 @@ func (b *Builder) HandleKey(msg tea.KeyMsg, onSubmit func() tea.Cmd) (tea.Cmd, b
 }
 
 func (b *Builder) Update(msg tea.Msg) tea.Cmd {
-	return b.root.Update(msg)
+	cmd := b.root.Update(msg)
+	b.root.RefreshPreview(b.placeholderContext(1))
+	return cmd
 }
 
 func (b *Builder) View(submitLabel string, active bool, disabled bool) string {
@@ -75,6 +88,11 @@ func (b *Builder) View(submitLabel string, active bool, disabled bool) string {
 			strings.Join(b.unsupportedFields, ", "))))
 		out.WriteString("\n")
 	}
+	if len(b.unfilled) > 0 {
+		out.WriteString(labelStyle.Render(fmt.Sprintf("(not filled: %s)",
+			strings.Join(b.unfilled, ", "))))
+		out.WriteString("\n")
+	}
 
 	out.WriteString("\n")
 	label := fmt.Sprintf("  [%s]", submitLabel)
@@ -100,6 +118,20 @@ func (b *Builder) Value() map[string]any {
 	return b.root.Value()
 }
 
+// SetVars sets the variables placeholders in the payload resolve against.
+func (b *Builder) SetVars(vars map[string]any) {
+	b.vars = vars
+}
+
+// Resolved returns the payload with its placeholders resolved for message seq.
+func (b *Builder) Resolved(seq int) map[string]any {
+	return placeholder.ExpandText(b.Value(), b.placeholderContext(seq))
+}
+
+func (b *Builder) placeholderContext(seq int) placeholder.Context {
+	return placeholder.Context{Seq: seq, Vars: b.vars}
+}
+
 func (b *Builder) ResetToSubmit() {
 	b.root.Blur()
 	b.submitFocused = true
@@ -136,7 +168,10 @@ func (b *Builder) prevField() {
 	b.root.PrevField()
 }
 
-func buildFieldGroup(msgDesc protoreflect.MessageDescriptor) *fieldGroup {
+// buildFieldGroup builds the top-level fields of msgDesc and returns the names
+// of the fields it can't build.
+func buildFieldGroup(msgDesc protoreflect.MessageDescriptor) (*fieldGroup, []string) {
+	var unsupported []string
 	g := &fieldGroup{
 		name:       "",
 		fields:     make([]Field, 0),
@@ -171,9 +206,11 @@ func buildFieldGroup(msgDesc protoreflect.MessageDescriptor) *fieldGroup {
 		}
 
 		formField := NewFieldFromProto(field)
-		if formField != nil {
-			g.fields = append(g.fields, *formField)
+		if formField == nil {
+			unsupported = append(unsupported, fieldName)
+			continue
 		}
+		g.fields = append(g.fields, *formField)
 	}
 
 	oneofs := msgDesc.Oneofs()
@@ -186,5 +223,35 @@ func buildFieldGroup(msgDesc protoreflect.MessageDescriptor) *fieldGroup {
 		}
 	}
 
-	return g
+	return g, unsupported
+}
+
+// nestsItself reports whether msg contains itself through singular message
+// fields. Groups build their fields up front, so such a message is only built
+// once it is expanded. Lists and maps build their items as they are added and
+// JSON fields have no fields to build, so neither counts.
+func nestsItself(msg protoreflect.MessageDescriptor) bool {
+	seen := make(map[protoreflect.FullName]bool)
+	var reaches func(protoreflect.MessageDescriptor) bool
+	reaches = func(m protoreflect.MessageDescriptor) bool {
+		fields := m.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			next := field.Message()
+			if next == nil || field.IsList() || field.IsMap() || jsonMessages[next.FullName()] {
+				continue
+			}
+			if next.FullName() == msg.FullName() {
+				return true
+			}
+			if !seen[next.FullName()] {
+				seen[next.FullName()] = true
+				if reaches(next) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return reaches(msg)
 }
@@ -0,0 +1,115 @@
+package call
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	featurev1 "github.com/prnvbn/grpcexp/cmd/testserver/feature"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// nodeMessage is a message that nests itself through a singular field as
+// well as a list.
+func nodeMessage(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("node.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Node"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("name"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				{Name: proto.String("next"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), TypeName: proto.String(".test.Node")},
+				{Name: proto.String("children"), Number: proto.Int32(3), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(), TypeName: proto.String(".test.Node")},
+			},
+		}},
+	}, new(protoregistry.Files))
+	if err != nil {
+		t.Fatalf("NewFile returned error: %v", err)
+	}
+	return file.Messages().ByName("Node")
+}
+
+func TestBuilderExpandsNestedMessages(t *testing.T) {
+	b := NewBuilder(nodeMessage(t))
+	if view := b.View("Submit", true, false); !strings.Contains(view, "[+] Expand") {
+		t.Fatalf("next is not collapsed:\n%s", view)
+	}
+	if got, want := b.Value(), map[string]any{"name": "", "children": []any{}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Value = %v, want %v", got, want)
+	}
+
+	b.HandleKey(tea.KeyMsg{Type: tea.KeyTab}, nil)
+	b.HandleKey(tea.KeyMsg{Type: tea.KeyEnter}, nil)
+
+	want := map[string]any{
+		"name":     "",
+		"next":     map[string]any{"name": "", "children": []any{}},
+		"children": []any{},
+	}
+	if got := b.Value(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Value after expanding next = %v, want %v", got, want)
+	}
+}
+
+func TestBuilderFillsNestedMessages(t *testing.T) {
+	b := NewBuilder(nodeMessage(t))
+	b.Fill(map[string]any{
+		"next": map[string]any{"next": map[string]any{"name": "c"}},
+		"children": []any{
+			map[string]any{"name": "d", "children": []any{map[string]any{"name": "e"}}},
+		},
+	})
+
+	want := map[string]any{
+		"name": "",
+		"next": map[string]any{
+			"name":     "",
+			"next":     map[string]any{"name": "c", "children": []any{}},
+			"children": []any{},
+		},
+		"children": []any{
+			map[string]any{"name": "d", "children": []any{
+				map[string]any{"name": "e", "children": []any{}},
+			}},
+		},
+	}
+	if got := b.Value(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Value = %v, want %v", got, want)
+	}
+	if len(b.unfilled) > 0 {
+		t.Fatalf("unfilled = %v, want none", b.unfilled)
+	}
+}
+
+func TestBuilderJSONFields(t *testing.T) {
+	b := NewBuilder((&featurev1.WellKnownMessage{}).ProtoReflect().Descriptor())
+	if _, ok := b.Value()["struct"]; ok {
+		t.Fatal("an empty struct is in the request")
+	}
+
+	b.Fill(map[string]any{
+		"struct":     map[string]any{"a": []any{1.0, true}},
+		"value":      "x",
+		"list_value": []any{1.0, nil},
+	})
+
+	got := b.Value()
+	want := map[string]any{
+		"struct":     map[string]any{"a": []any{1.0, true}},
+		"value":      "x",
+		"list_value": []any{1.0, nil},
+	}
+	for name, value := range want {
+		if !reflect.DeepEqual(got[name], value) {
+			t.Errorf("Value()[%q] = %v, want %v", name, got[name], value)
+		}
+	}
+}
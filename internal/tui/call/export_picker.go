@@ -0,0 +1,75 @@
+package call
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/export"
+	"github.com/prnvbn/grpcexp/internal/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// exportPicker chooses the format the request is copied to the clipboard in.
+type exportPicker struct {
+	formats []export.Format
+	cursor  int
+	err     string
+}
+
+func newExportPicker() *exportPicker {
+	return &exportPicker{formats: export.Formats()}
+}
+
+// HandleKey moves between formats and reports whether one was chosen.
+func (p *exportPicker) HandleKey(msg tea.KeyMsg) (export.Format, bool) {
+	switch msg.String() {
+	case "up", "shift+tab":
+		p.cursor = (p.cursor + len(p.formats) - 1) % len(p.formats)
+	case "down", "tab":
+		p.cursor = (p.cursor + 1) % len(p.formats)
+	case "enter":
+		return p.formats[p.cursor], true
+	}
+	return export.Format{}, false
+}
+
+// copy copies request exported by format, keeping the picker open with the
+// error if it fails.
+func (p *exportPicker) copy(format export.Format, client *grpc.Client, method protoreflect.MethodDescriptor, request map[string]any) bool {
+	r, err := client.ExportRequest(method, request)
+	if err == nil {
+		var text string
+		text, err = format.Export(r)
+		if err == nil {
+			err = clipboard.WriteAll(text)
+		}
+	}
+	if err != nil {
+		p.err = err.Error()
+		return false
+	}
+	return true
+}
+
+func (p *exportPicker) View() string {
+	var out strings.Builder
+
+	out.WriteString(focusedLabelStyle.Render("Copy request as"))
+	out.WriteString("\n")
+	for i, format := range p.formats {
+		if i == p.cursor {
+			out.WriteString(selectedStyle.Render("> " + format.Name))
+		} else {
+			out.WriteString(unselectedStyle.Render("  " + format.Name))
+		}
+		out.WriteString("\n")
+	}
+	if p.err != "" {
+		out.WriteString(labelStyle.Render("  " + p.err))
+		out.WriteString("\n")
+	}
+	out.WriteString(labelStyle.Render("up/down: select • enter: copy • esc: cancel"))
+
+	return out.String()
+}
@@ -1,6 +1,7 @@
 package call
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/placeholder"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -35,6 +37,14 @@ type Field struct {
 	oneofField *fieldOneof
 
 	validate func(string) error
+	// decodeJSON is set on text fields holding a JSON value, e.g. a
+	// google.protobuf.Struct
+	decodeJSON bool
+
+	// preview is the text value with its placeholders resolved, previewOf the
+	// value it was resolved from
+	preview   string
+	previewOf string
 }
 
 func NewTextField(name string, placeholder string, charLimit int, validate func(string) error) *Field {
@@ -50,6 +60,13 @@ func NewTextField(name string, placeholder string, charLimit int, validate func(
 	}
 }
 
+// NewJSONField returns a text field whose value is decoded as JSON.
+func NewJSONField(name string, placeholder string) *Field {
+	f := NewTextField(name, placeholder, 0, validateJSON)
+	f.decodeJSON = true
+	return f
+}
+
 func NewBoolField(name string) *Field {
 	items := []enumItem{
 		{name: "false", value: "false"},
@@ -84,8 +101,15 @@ func NewEnumField(name string, field protoreflect.FieldDescriptor) *Field {
 }
 
 func NewFieldGroup(name string, field protoreflect.FieldDescriptor) *Field {
-	fields := field.Message().Fields()
-	fg := NewfieldGroup(name, fields)
+	msgDesc := field.Message()
+
+	var fg *fieldGroup
+	if nestsItself(msgDesc) {
+		fg = newCollapsedFieldGroup(name, msgDesc)
+	} else {
+		fg, _ = buildFieldGroup(msgDesc)
+		fg.name = name
+	}
 	return &Field{
 		name:       name,
 		kind:       FieldGroup,
@@ -142,6 +166,14 @@ func validateUint(s string) error {
 	return nil
 }
 
+func validatePositiveInt(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
 func validateFloat(s string) error {
 	if s == "" {
 		return nil
@@ -179,6 +211,24 @@ func validateTimestamp(s string) error {
 	return nil
 }
 
+func validateJSON(s string) error {
+	if s == "" {
+		return nil
+	}
+	if !json.Valid([]byte(s)) {
+		return fmt.Errorf("must be valid JSON")
+	}
+	return nil
+}
+
+// jsonMessages are the well-known types entered as JSON, as their JSON form
+// isn't an object of their fields.
+var jsonMessages = map[protoreflect.FullName]bool{
+	"google.protobuf.Struct":    true,
+	"google.protobuf.Value":     true,
+	"google.protobuf.ListValue": true,
+}
+
 func NewFieldFromProto(field protoreflect.FieldDescriptor) *Field {
 	return newFieldFromProto(field, "")
 }
@@ -220,9 +270,11 @@ func newFieldFromProto(field protoreflect.FieldDescriptor, inputRole string) *Fi
 			return NewTextField(name, textFieldPlaceholder(field, inputRole), 64, validateTimestamp)
 		case "google.protobuf.Duration":
 			return NewTextField(name, textFieldPlaceholder(field, inputRole), 64, validateDuration)
-		default:
-			return NewFieldGroup(name, field)
 		}
+		if jsonMessages[msgDesc.FullName()] {
+			return NewJSONField(name, textFieldPlaceholder(field, inputRole))
+		}
+		return NewFieldGroup(name, field)
 	default:
 		return nil
 	}
@@ -263,6 +315,12 @@ func textFieldPlaceholder(field protoreflect.FieldDescriptor, inputRole string)
 			}
 			return "Enter duration (e.g., 10s)..."
 		}
+		if jsonMessages[field.Message().FullName()] {
+			if inputRole != "" {
+				return fmt.Sprintf("Enter JSON %s...", inputRole)
+			}
+			return `Enter JSON (e.g., {"a": [1, true]})...`
+		}
 	}
 	return fmt.Sprintf("Enter %s...", field.Name())
 }
@@ -270,10 +328,16 @@ func textFieldPlaceholder(field protoreflect.FieldDescriptor, inputRole string)
 func (f *Field) Value() any {
 	switch f.kind {
 	case FieldText:
+		if f.decodeJSON {
+			return decodeJSON(f.textInput.Value())
+		}
 		return f.textInput.Value()
 	case FieldEnum, FieldBool:
 		return f.enumPicker.Value()
 	case FieldGroup:
+		if f.fieldGroup.collapsed {
+			return nil
+		}
 		return f.fieldGroup.Value()
 	case FieldList:
 		return f.listField.Value()
@@ -286,9 +350,25 @@ func (f *Field) Value() any {
 	}
 }
 
+// decodeJSON decodes the text of a JSON field, which is left out of the request
+// when empty. Invalid JSON is sent as the text for the server to reject.
+func decodeJSON(text string) any {
+	if text == "" {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal([]byte(text), &v); err != nil {
+		return text
+	}
+	return v
+}
+
 func (f *Field) View() string {
 	switch f.kind {
 	case FieldText:
+		if f.preview != "" {
+			return f.textInput.View() + labelStyle.Render(" → "+f.preview)
+		}
 		return f.textInput.View()
 	case FieldEnum, FieldBool:
 		return f.enumPicker.View()
@@ -519,6 +599,37 @@ func (f *Field) SetWidth(width int) {
 	}
 }
 
+// RefreshPreview resolves the placeholders of text fields whose value changed
+// since the last refresh.
+func (f *Field) RefreshPreview(ctx placeholder.Context) {
+	switch f.kind {
+	case FieldText:
+		value := f.textInput.Value()
+		if value == f.previewOf {
+			return
+		}
+		f.previewOf = value
+		f.preview = ""
+		if placeholder.Contains(value) {
+			f.preview = placeholder.ExpandString(value, ctx)
+		}
+	case FieldGroup:
+		f.fieldGroup.RefreshPreview(ctx)
+	case FieldList:
+		if f.listField != nil {
+			f.listField.RefreshPreview(ctx)
+		}
+	case FieldMap:
+		if f.mapField != nil {
+			f.mapField.RefreshPreview(ctx)
+		}
+	case FieldOneof:
+		if f.oneofField != nil {
+			f.oneofField.RefreshPreview(ctx)
+		}
+	}
+}
+
 func (f *Field) RenderWithFocus(focused bool, depth int) string {
 	var b strings.Builder
 	indent := strings.Repeat("  ", depth)
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/placeholder"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -12,24 +13,34 @@ type fieldGroup struct {
 	fields     []Field
 	focusIndex int
 	focused    bool
+	width      int
+
+	// collapsed groups are left out of the request and build the fields of
+	// desc when expanded
+	collapsed bool
+	desc      protoreflect.MessageDescriptor
 }
 
-func NewfieldGroup(name string, protoFields protoreflect.FieldDescriptors) *fieldGroup {
-	fields := make([]Field, 0)
-	for i := 0; i < protoFields.Len(); i++ {
-		protoField := protoFields.Get(i)
-		field := NewFieldFromProto(protoField)
-		if field != nil {
-			fields = append(fields, *field)
-		}
+// newCollapsedFieldGroup returns a group for a message that nests itself,
+// whose fields are built on demand as building them up front never ends.
+func newCollapsedFieldGroup(name string, msgDesc protoreflect.MessageDescriptor) *fieldGroup {
+	return &fieldGroup{
+		name:      name,
+		fields:    make([]Field, 0),
+		collapsed: true,
+		desc:      msgDesc,
 	}
+}
 
-	return &fieldGroup{
-		name:       name,
-		fields:     fields,
-		focusIndex: 0,
-		focused:    false,
+// expand builds the fields of a collapsed group.
+func (g *fieldGroup) expand() {
+	if !g.collapsed {
+		return
 	}
+	built, _ := buildFieldGroup(g.desc)
+	g.fields = built.fields
+	g.collapsed = false
+	g.SetWidth(g.width)
 }
 
 func (g *fieldGroup) Empty() bool {
@@ -46,7 +57,9 @@ func (g *fieldGroup) Value() map[string]any {
 			}
 			continue
 		}
-		fields[field.name] = field.Value()
+		if value := field.Value(); value != nil {
+			fields[field.name] = value
+		}
 	}
 	return fields
 }
@@ -97,6 +110,10 @@ func (g *fieldGroup) blurChild(idx int) {
 }
 
 func (g *fieldGroup) FocusFirst() {
+	if g.collapsed {
+		g.focused = true
+		return
+	}
 	if len(g.fields) == 0 {
 		return
 	}
@@ -106,6 +123,10 @@ func (g *fieldGroup) FocusFirst() {
 }
 
 func (g *fieldGroup) FocusLast() {
+	if g.collapsed {
+		g.focused = true
+		return
+	}
 	if len(g.fields) == 0 {
 		return
 	}
@@ -272,6 +293,17 @@ func (g *fieldGroup) AcceptsTextInput() bool {
 }
 
 func (g *fieldGroup) HandleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if g.collapsed {
+		switch msg.String() {
+		case "enter", " ":
+			if g.focused {
+				g.expand()
+				g.FocusFirst()
+				return nil, true
+			}
+		}
+		return nil, false
+	}
 	if !g.focused || len(g.fields) == 0 {
 		return nil, false
 	}
@@ -330,11 +362,18 @@ func (g *fieldGroup) Update(msg tea.Msg) tea.Cmd {
 }
 
 func (g *fieldGroup) SetWidth(width int) {
+	g.width = width
 	for i := range g.fields {
 		g.fields[i].SetWidth(width)
 	}
 }
 
+func (g *fieldGroup) RefreshPreview(ctx placeholder.Context) {
+	for i := range g.fields {
+		g.fields[i].RefreshPreview(ctx)
+	}
+}
+
 func (g *fieldGroup) View() string {
 	return g.ViewWithDepth(1)
 }
@@ -343,6 +382,16 @@ func (g *fieldGroup) ViewWithDepth(depth int) string {
 	var b strings.Builder
 	indent := strings.Repeat("  ", depth)
 
+	if g.collapsed {
+		if g.focused {
+			b.WriteString(focusedLabelStyle.Render(indent + "> [+] Expand"))
+		} else {
+			b.WriteString(labelStyle.Render(indent + "  [+] Expand"))
+		}
+		b.WriteString("\n")
+		return b.String()
+	}
+
 	for i, field := range g.fields {
 		isFocused := g.focused && i == g.focusIndex
 
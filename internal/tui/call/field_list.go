@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/placeholder"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -307,6 +308,12 @@ func (l *fieldList) SetWidth(width int) {
 	}
 }
 
+func (l *fieldList) RefreshPreview(ctx placeholder.Context) {
+	for i := range l.items {
+		l.items[i].RefreshPreview(ctx)
+	}
+}
+
 func (l *fieldList) View() string {
 	return l.ViewWithDepth(0)
 }
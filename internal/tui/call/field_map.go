@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/placeholder"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -411,6 +412,13 @@ func (m *fieldMap) SetWidth(width int) {
 	}
 }
 
+func (m *fieldMap) RefreshPreview(ctx placeholder.Context) {
+	for i := range m.entries {
+		m.entries[i].key.RefreshPreview(ctx)
+		m.entries[i].value.RefreshPreview(ctx)
+	}
+}
+
 func (m *fieldMap) View() string {
 	return m.ViewWithDepth(0)
 }
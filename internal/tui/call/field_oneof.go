@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/placeholder"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -26,22 +27,22 @@ type fieldOneof struct {
 func newFieldOneof(name string, oneof protoreflect.OneofDescriptor) *fieldOneof {
 	protoFields := oneof.Fields()
 
-	items := make([]enumItem, protoFields.Len())
-	fields := make([]Field, protoFields.Len())
+	items := make([]enumItem, 0, protoFields.Len())
+	fields := make([]Field, 0, protoFields.Len())
 
 	for i := 0; i < protoFields.Len(); i++ {
 		protoField := protoFields.Get(i)
 		fieldName := string(protoField.Name())
 
-		items[i] = enumItem{
-			name:  fieldName,
-			value: fieldName,
-		}
-
 		field := NewFieldFromProto(protoField)
-		if field != nil {
-			fields[i] = *field
+		if field == nil {
+			continue
 		}
+		items = append(items, enumItem{
+			name:  fieldName,
+			value: fieldName,
+		})
+		fields = append(fields, *field)
 	}
 
 	return &fieldOneof{
@@ -65,7 +66,9 @@ func (o *fieldOneof) Value() map[string]any {
 	result := make(map[string]any)
 	field := o.selectedField()
 	if field != nil {
-		result[field.name] = field.Value()
+		if value := field.Value(); value != nil {
+			result[field.name] = value
+		}
 	}
 	return result
 }
@@ -201,6 +204,12 @@ func (o *fieldOneof) SetWidth(width int) {
 	}
 }
 
+func (o *fieldOneof) RefreshPreview(ctx placeholder.Context) {
+	for i := range o.fields {
+		o.fields[i].RefreshPreview(ctx)
+	}
+}
+
 func (o *fieldOneof) View() string {
 	return o.ViewWithDepth(1)
 }
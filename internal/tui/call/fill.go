@@ -0,0 +1,181 @@
+package call
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Fill sets the fields of the builder from request, e.g. the -d of a grpcurl
+// command. Keys may be proto or JSON field names. Values without a field to
+// hold them are listed below the fields.
+func (b *Builder) Fill(request map[string]any) {
+	b.unfilled = b.root.fill(request, "")
+	b.root.RefreshPreview(b.placeholderContext(1))
+}
+
+// fill sets the fields of the group from values and returns the paths of the
+// values it has no field for.
+func (g *fieldGroup) fill(values map[string]any, path string) []string {
+	var unfilled []string
+	for _, key := range sortedKeys(values) {
+		field := g.fieldFor(key)
+		if field == nil {
+			unfilled = append(unfilled, path+key)
+			continue
+		}
+		unfilled = append(unfilled, field.fill(values[key], path+key)...)
+	}
+	return unfilled
+}
+
+// fieldFor finds the field named key, selecting the case of a oneof it is in.
+func (g *fieldGroup) fieldFor(key string) *Field {
+	for i := range g.fields {
+		field := &g.fields[i]
+		if field.kind != FieldOneof {
+			if fieldNameMatches(field.name, key) {
+				return field
+			}
+			continue
+		}
+		o := field.oneofField
+		for j := range o.fields {
+			if fieldNameMatches(o.fields[j].name, key) {
+				o.selectedIndex = j
+				o.picker.selected = j
+				return &o.fields[j]
+			}
+		}
+	}
+	return nil
+}
+
+func (f *Field) fill(value any, path string) []string {
+	if value == nil {
+		return nil
+	}
+
+	switch f.kind {
+	case FieldText:
+		if f.decodeJSON {
+			if data, err := json.Marshal(value); err == nil {
+				f.textInput.SetValue(string(data))
+				return nil
+			}
+		}
+		if text, ok := scalarText(value); ok {
+			f.textInput.SetValue(text)
+			return nil
+		}
+	case FieldBool, FieldEnum:
+		if text, ok := scalarText(value); ok && f.enumPicker.choose(text) {
+			return nil
+		}
+	case FieldGroup:
+		if values, ok := value.(map[string]any); ok {
+			f.fieldGroup.expand()
+			return f.fieldGroup.fill(values, path+".")
+		}
+	case FieldList:
+		if items, ok := value.([]any); ok {
+			return f.listField.fill(items, path)
+		}
+	case FieldMap:
+		if entries, ok := value.(map[string]any); ok {
+			return f.mapField.fill(entries, path)
+		}
+	}
+	return []string{path}
+}
+
+func (l *fieldList) fill(items []any, path string) []string {
+	var unfilled []string
+	for i, item := range items {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		count := len(l.items)
+		l.AddItem()
+		if len(l.items) == count {
+			unfilled = append(unfilled, itemPath)
+			continue
+		}
+		unfilled = append(unfilled, l.items[len(l.items)-1].fill(item, itemPath)...)
+	}
+	return unfilled
+}
+
+func (m *fieldMap) fill(entries map[string]any, path string) []string {
+	var unfilled []string
+	for _, key := range sortedKeys(entries) {
+		entryPath := fmt.Sprintf("%s[%s]", path, key)
+		count := len(m.entries)
+		m.AddEntry()
+		if len(m.entries) == count {
+			unfilled = append(unfilled, entryPath)
+			continue
+		}
+		entry := &m.entries[len(m.entries)-1]
+		unfilled = append(unfilled, entry.key.fill(key, entryPath)...)
+		unfilled = append(unfilled, entry.value.fill(entries[key], entryPath)...)
+	}
+	return unfilled
+}
+
+// choose selects the item with the given name or value.
+func (p *enumPicker) choose(s string) bool {
+	for i, item := range p.items {
+		if item.name == s || item.value == s {
+			p.selected = i
+			return true
+		}
+	}
+	return false
+}
+
+// scalarText is the text a decoded JSON scalar is entered as.
+func scalarText(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+// fieldNameMatches reports whether key is the proto name of a field or its
+// JSON name, e.g. user_id or userId.
+func fieldNameMatches(name, key string) bool {
+	return key == name || key == jsonName(name)
+}
+
+// jsonName is the default JSON name protoc gives a field.
+func jsonName(name string) string {
+	var out strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			out.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
+
+func sortedKeys(m map[string]any) []string {
+	return slices.Sorted(maps.Keys(m))
+}
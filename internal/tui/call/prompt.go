@@ -0,0 +1,120 @@
+package call
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prompt is a small inline form of labelled text inputs used for actions
+// that need a few parameters, like picking a file to send.
+type prompt struct {
+	title  string
+	inputs []promptInput
+	focus  int
+	err    string
+}
+
+type promptInput struct {
+	label    string
+	input    textinput.Model
+	validate func(string) error
+}
+
+func newPromptInput(label, placeholder, value string, validate func(string) error) promptInput {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.Prompt = ""
+	ti.SetValue(value)
+	return promptInput{
+		label:    label,
+		input:    ti,
+		validate: validate,
+	}
+}
+
+func newPrompt(title string, inputs ...promptInput) *prompt {
+	p := &prompt{
+		title:  title,
+		inputs: inputs,
+	}
+	p.inputs[0].input.Focus()
+	return p
+}
+
+// HandleKey moves between inputs and reports whether the prompt was submitted
+// with valid values.
+func (p *prompt) HandleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "tab", "down":
+		return p.setFocus((p.focus + 1) % len(p.inputs)), false
+	case "shift+tab", "up":
+		return p.setFocus((p.focus + len(p.inputs) - 1) % len(p.inputs)), false
+	case "enter":
+		if p.focus < len(p.inputs)-1 {
+			return p.setFocus(p.focus + 1), false
+		}
+		for i, in := range p.inputs {
+			if in.validate == nil {
+				continue
+			}
+			if err := in.validate(in.input.Value()); err != nil {
+				p.err = in.label + ": " + err.Error()
+				return p.setFocus(i), false
+			}
+		}
+		return nil, true
+	}
+
+	p.err = ""
+	var cmd tea.Cmd
+	p.inputs[p.focus].input, cmd = p.inputs[p.focus].input.Update(msg)
+	return cmd, false
+}
+
+func (p *prompt) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	p.inputs[p.focus].input, cmd = p.inputs[p.focus].input.Update(msg)
+	return cmd
+}
+
+func (p *prompt) Value(i int) string {
+	return strings.TrimSpace(p.inputs[i].input.Value())
+}
+
+func (p *prompt) SetWidth(width int) {
+	for i := range p.inputs {
+		p.inputs[i].input.Width = width
+	}
+}
+
+func (p *prompt) View() string {
+	var out strings.Builder
+
+	out.WriteString(focusedLabelStyle.Render(p.title))
+	out.WriteString("\n")
+	for i, in := range p.inputs {
+		label := "  " + in.label + ": "
+		if i == p.focus {
+			out.WriteString(focusedLabelStyle.Render("> " + in.label + ": "))
+		} else {
+			out.WriteString(labelStyle.Render(label))
+		}
+		out.WriteString(in.input.View())
+		out.WriteString("\n")
+	}
+	if p.err != "" {
+		out.WriteString(labelStyle.Render("  " + p.err))
+		out.WriteString("\n")
+	}
+	out.WriteString(labelStyle.Render("enter: confirm • esc: cancel"))
+
+	return out.String()
+}
+
+func (p *prompt) setFocus(i int) tea.Cmd {
+	p.inputs[p.focus].input.Blur()
+	p.focus = i
+	return p.inputs[p.focus].input.Focus()
+}
@@ -0,0 +1,340 @@
+package call
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/collection"
+	"github.com/prnvbn/grpcexp/internal/proxy"
+	"github.com/prnvbn/grpcexp/internal/transcript"
+)
+
+// ProxyViewer lists the calls passing through a recording proxy as they
+// happen and shows each one as a transcript.
+type ProxyViewer struct {
+	proxy    *proxy.Proxy
+	listen   string
+	upstream string
+
+	calls  []proxy.Call
+	cursor int
+	offset int
+	// follow keeps the cursor on the newest call as calls arrive
+	follow bool
+	height int
+	width  int
+
+	detail *proxyDetail
+	prompt *prompt
+	// notice reports the result of the last action until the next key press
+	notice string
+}
+
+// proxyDetail is the transcript of the call being viewed, kept up to date
+// while the call is in progress.
+type proxyDetail struct {
+	id         int
+	transcript transcriptView
+	entries    int
+	recvCount  int
+}
+
+type proxyChangedMsg struct{}
+
+// NewProxyViewer shows the calls recorded by p, which listens on listen and
+// forwards to upstream.
+func NewProxyViewer(p *proxy.Proxy, listen, upstream string) *ProxyViewer {
+	return &ProxyViewer{proxy: p, listen: listen, upstream: upstream, follow: true}
+}
+
+func (v *ProxyViewer) Init() tea.Cmd {
+	return v.waitForChange()
+}
+
+func (v *ProxyViewer) waitForChange() tea.Cmd {
+	changed := v.proxy.Changed()
+	return func() tea.Msg {
+		<-changed
+		return proxyChangedMsg{}
+	}
+}
+
+func (v *ProxyViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case proxyChangedMsg:
+		v.refresh()
+		return v, v.waitForChange()
+	case tea.WindowSizeMsg:
+		v.width, v.height = msg.Width, msg.Height
+		if v.prompt != nil {
+			v.prompt.SetWidth(v.width - 12)
+		}
+		if v.detail != nil {
+			v.detail.transcript.SetSize(v.height, v.detailRows())
+		}
+		v.scrollToCursor()
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return v, tea.Quit
+		}
+		v.notice = ""
+		if v.prompt != nil {
+			return v, v.handlePromptKey(msg)
+		}
+		if v.detail != nil {
+			return v, v.handleDetailKey(msg)
+		}
+		return v, v.handleListKey(msg)
+	}
+	return v, nil
+}
+
+func (v *ProxyViewer) handleListKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "q", "esc":
+		return tea.Quit
+	case "up":
+		v.moveCursor(-1)
+	case "down":
+		v.moveCursor(1)
+	case "pgup":
+		v.moveCursor(-max(v.listRows()/2, 1))
+	case "pgdown":
+		v.moveCursor(max(v.listRows()/2, 1))
+	case "enter":
+		if v.cursor < len(v.calls) {
+			v.openDetail(v.calls[v.cursor].ID)
+		}
+	case "x":
+		v.proxy.Clear()
+		v.calls = nil
+		v.cursor, v.offset, v.follow = 0, 0, true
+	case "ctrl+s":
+		v.openSavePrompt()
+	}
+	return nil
+}
+
+func (v *ProxyViewer) handleDetailKey(msg tea.KeyMsg) tea.Cmd {
+	t := &v.detail.transcript
+	if t.Capturing() && msg.String() != "esc" {
+		cmd, _ := t.HandleKey(msg)
+		return cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		if !t.Back() {
+			v.detail = nil
+		}
+		return nil
+	case "ctrl+y":
+		copyToClipboard(t.PlainText())
+		return nil
+	}
+	cmd, _ := t.HandleKey(msg)
+	return cmd
+}
+
+func (v *ProxyViewer) handlePromptKey(msg tea.KeyMsg) tea.Cmd {
+	if msg.String() == "esc" {
+		v.prompt = nil
+		return nil
+	}
+	cmd, submitted := v.prompt.HandleKey(msg)
+	if submitted {
+		v.save(v.prompt.Value(0))
+		v.prompt = nil
+	}
+	return cmd
+}
+
+func (v *ProxyViewer) openSavePrompt() {
+	if len(v.finished()) == 0 {
+		v.notice = "no finished calls to save"
+		return
+	}
+	v.prompt = newPrompt("Save finished calls as a collection of requests",
+		newPromptInput("file", "path/to/requests.yaml", "requests.yaml", nil),
+	)
+	v.prompt.SetWidth(v.width - 12)
+}
+
+func (v *ProxyViewer) save(path string) {
+	c := proxy.Collection(v.upstream, v.finished())
+	if err := collection.Save(path, c); err != nil {
+		v.notice = "failed to save requests: " + err.Error()
+		return
+	}
+	v.notice = fmt.Sprintf("saved %d requests to %s", len(c.Requests), path)
+}
+
+func (v *ProxyViewer) finished() []proxy.Call {
+	var calls []proxy.Call
+	for _, call := range v.calls {
+		if call.Done() {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (v *ProxyViewer) refresh() {
+	v.calls = v.proxy.Calls()
+	if v.follow || v.cursor >= len(v.calls) {
+		v.cursor = max(len(v.calls)-1, 0)
+	}
+	v.scrollToCursor()
+
+	if v.detail != nil {
+		if call, ok := v.call(v.detail.id); ok {
+			v.appendEntries(call)
+		}
+	}
+}
+
+func (v *ProxyViewer) call(id int) (proxy.Call, bool) {
+	for _, call := range v.calls {
+		if call.ID == id {
+			return call, true
+		}
+	}
+	return proxy.Call{}, false
+}
+
+func (v *ProxyViewer) openDetail(id int) {
+	v.detail = &proxyDetail{id: id, transcript: newTranscriptView(0)}
+	v.detail.transcript.SetSize(v.height, v.detailRows())
+	if call, ok := v.call(id); ok {
+		v.appendEntries(call)
+	}
+}
+
+// appendEntries adds the entries recorded since the detail was last updated.
+func (v *ProxyViewer) appendEntries(call proxy.Call) {
+	d := v.detail
+	for _, entry := range call.Entries[d.entries:] {
+		if entry.Direction == transcript.Received {
+			d.recvCount++
+		}
+		d.transcript.Append(newTranscriptEntry(entry, d.recvCount))
+	}
+	d.entries = len(call.Entries)
+}
+
+func (v *ProxyViewer) moveCursor(delta int) {
+	if len(v.calls) == 0 {
+		return
+	}
+	v.cursor = min(max(v.cursor+delta, 0), len(v.calls)-1)
+	v.follow = v.cursor == len(v.calls)-1
+	v.scrollToCursor()
+}
+
+func (v *ProxyViewer) scrollToCursor() {
+	rows := v.listRows()
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+rows {
+		v.offset = v.cursor - rows + 1
+	}
+	v.offset = max(min(v.offset, len(v.calls)-rows), 0)
+}
+
+func (v *ProxyViewer) listRows() int {
+	return max(v.height-8, 3)
+}
+
+func (v *ProxyViewer) detailRows() int {
+	rows := v.height - 10
+	if call, ok := v.call(v.detail.id); ok {
+		rows -= len(call.Metadata)
+	}
+	return rows
+}
+
+func (v *ProxyViewer) View() string {
+	var out strings.Builder
+
+	out.WriteString(headerStyle.Render(fmt.Sprintf("proxy %s → %s", v.listen, v.upstream)))
+	out.WriteString("\n")
+
+	if v.detail != nil {
+		out.WriteString(v.detailView())
+		return out.String()
+	}
+
+	if len(v.calls) == 0 {
+		out.WriteString(labelStyle.Render("Waiting for calls..."))
+		out.WriteString("\n")
+	} else {
+		out.WriteString(labelStyle.Render(fmt.Sprintf("  %5s  %-16s  %9s  %5s  %5s  %s", "#", "status", "time", "sent", "recv", "method")))
+		out.WriteString("\n")
+		end := min(v.offset+v.listRows(), len(v.calls))
+		for i := v.offset; i < end; i++ {
+			line := callLine(v.calls[i])
+			if i == v.cursor {
+				out.WriteString(selectedStyle.Render("▌ " + line))
+			} else {
+				out.WriteString("  " + line)
+			}
+			out.WriteString("\n")
+		}
+	}
+
+	out.WriteString("\n")
+	if v.prompt != nil {
+		out.WriteString(v.prompt.View())
+		return out.String()
+	}
+	if v.notice != "" {
+		out.WriteString(labelStyle.Render(v.notice))
+		out.WriteString("\n")
+	}
+	out.WriteString(labelStyle.Render("up/down: select • enter: open • ctrl+s: save as requests • x: clear • q: quit"))
+	return out.String()
+}
+
+func (v *ProxyViewer) detailView() string {
+	var out strings.Builder
+
+	t := &v.detail.transcript
+	if t.Detail() {
+		out.WriteString(t.DetailView())
+		return out.String()
+	}
+
+	call, _ := v.call(v.detail.id)
+	out.WriteString(focusedLabelStyle.Render(fmt.Sprintf("#%d %s", call.ID, call.Method)))
+	out.WriteString("\n")
+	out.WriteString(labelStyle.Render(fmt.Sprintf("%s • %s", callStatus(call), call.Start.Format("15:04:05.000"))))
+	out.WriteString("\n")
+	if len(call.Metadata) > 0 {
+		out.WriteString(labelStyle.Render("> metadata" + metadataText(call.Metadata)))
+		out.WriteString("\n")
+	}
+	out.WriteString("\n")
+	out.WriteString(t.View(true))
+	out.WriteString("\n\n")
+	out.WriteString(labelStyle.Render(t.Help() + " • ctrl+y: copy transcript • esc: back"))
+	return out.String()
+}
+
+func callLine(call proxy.Call) string {
+	elapsed := "-"
+	if call.Done() {
+		elapsed = call.Duration.Round(time.Microsecond).String()
+	}
+	return fmt.Sprintf("%5d  %-16s  %9s  %5d  %5d  %s",
+		call.ID, callStatus(call), elapsed, len(call.Requests()), len(call.Responses()), call.Method)
+}
+
+func callStatus(call proxy.Call) string {
+	if !call.Done() {
+		return "in progress"
+	}
+	return call.Status.Code().String()
+}
@@ -12,14 +12,27 @@ type Screen interface {
 	tea.Model
 	SetSize(width, height int)
 	AcceptsTextInput() bool
+	// Back handles esc within the screen, e.g. closing a prompt. It reports
+	// false when the screen itself should be closed.
+	Back() bool
 	Cancel()
+	// Fill sets the request being built, e.g. from a grpcurl command.
+	Fill(request map[string]any)
 }
 
-func NewScreen(method protoreflect.MethodDescriptor, client *grpc.Client) Screen {
+// Options configure the call screens.
+type Options struct {
+	// TranscriptLimit caps the entries kept in a stream transcript, 0 keeps them all.
+	TranscriptLimit int
+	// Vars fill the {{name}} placeholders of requests, e.g. from a profile.
+	Vars map[string]any
+}
+
+func NewScreen(method protoreflect.MethodDescriptor, client *grpc.Client, opts Options) Screen {
 	if method.IsStreamingClient() || method.IsStreamingServer() {
-		return NewStream(method, client)
+		return NewStream(method, client, opts)
 	}
-	return NewUnary(method, client)
+	return NewUnary(method, client, opts)
 }
 
 func callHeader(method protoreflect.MethodDescriptor) string {
@@ -36,3 +49,11 @@ func callHeader(method protoreflect.MethodDescriptor) string {
 	header := fmt.Sprintf("%s(%s) -> %s", method.FullName(), input, output)
 	return headerStyle.Render(header)
 }
+
+// disconnectedNotice is shown on call screens while the client has no connection.
+func disconnectedNotice(client *grpc.Client) string {
+	if client.Connected() {
+		return ""
+	}
+	return labelStyle.Render("disconnected • connects on submit") + "\n\n"
+}
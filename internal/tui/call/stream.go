@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/prnvbn/grpcexp/internal/grpc"
+	"github.com/prnvbn/grpcexp/internal/placeholder"
+	"github.com/prnvbn/grpcexp/internal/transcript"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -31,39 +34,72 @@ type Stream struct {
 	width   int
 	height  int
 
-	activePane  streamPane
-	started     bool
-	sendClosed  bool
-	closed      bool
-	cancel      context.CancelFunc
-	requests    chan map[string]any
-	events      chan grpc.StreamEvent
-	transcript  []transcriptEntry
-	recvCount   int
-	scrollIndex int
-	timestamps  bool
-	generation  int
+	activePane streamPane
+	started    bool
+	sendClosed bool
+	closed     bool
+	cancel     context.CancelFunc
+	requests   chan map[string]any
+	events     chan grpc.StreamEvent
+	transcript transcriptView
+	stats      streamStats
+	generation int
+
+	// prompt collects parameters for an action and runs onPromptSubmit when confirmed
+	prompt         *prompt
+	onPromptSubmit func(*prompt) tea.Cmd
+	// exporter picks the format the request is copied in
+	exporter *exportPicker
+
+	// batch is the file or repeated send in progress, if any
+	batch *sendBatch
+	// notice reports the result of the last action until the next key press
+	notice string
 }
 
-type transcriptEntry struct {
-	at   time.Time
-	text string
+// sendBatch sends a series of messages, one every delay.
+type sendBatch struct {
+	// source names where messages come from in progress and transcript lines
+	source string
+	next   func(seq int) map[string]any
+	sent   int
+	total  int
+	delay  time.Duration
 }
 
-type streamEventMsg struct {
+// maxEventBatch bounds how many buffered stream events are handled per update.
+const maxEventBatch = 512
+
+// batchRetryDelay is how long a batch waits for the stream to take a message
+// when its request buffer is full.
+const batchRetryDelay = 50 * time.Millisecond
+
+type streamEventsMsg struct {
+	generation int
+	events     []grpc.StreamEvent
+}
+
+type statsTickMsg struct {
 	generation int
-	event      grpc.StreamEvent
 }
 
 type streamDoneMsg struct {
 	generation int
 }
 
-func NewStream(method protoreflect.MethodDescriptor, client *grpc.Client) *Stream {
+type batchSendMsg struct {
+	generation int
+	batch      *sendBatch
+}
+
+func NewStream(method protoreflect.MethodDescriptor, client *grpc.Client, opts Options) *Stream {
+	builder := NewBuilder(method.Input())
+	builder.SetVars(opts.Vars)
 	return &Stream{
-		method:  method,
-		builder: NewBuilder(method.Input()),
-		client:  client,
+		method:     method,
+		builder:    builder,
+		client:     client,
+		transcript: newTranscriptView(opts.TranscriptLimit),
 	}
 }
 
@@ -73,16 +109,27 @@ func (f *Stream) Init() tea.Cmd {
 
 func (f *Stream) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
-	case streamEventMsg:
+	case streamEventsMsg:
 		if msg.generation != f.generation {
 			return f, nil
 		}
-		return f, f.handleStreamEvent(msg.event)
+		return f, f.handleStreamEvents(msg.events)
+	case statsTickMsg:
+		if msg.generation != f.generation || f.closed {
+			return f, nil
+		}
+		f.stats.Tick(time.Now())
+		return f, f.tickStats()
 	case streamDoneMsg:
 		if msg.generation != f.generation {
 			return f, nil
 		}
 		return f, nil
+	case batchSendMsg:
+		if msg.generation != f.generation || msg.batch != f.batch {
+			return f, nil
+		}
+		return f, f.sendNextInBatch()
 	case tea.KeyMsg:
 		cmd, handled := f.handleKey(msg)
 		if handled {
@@ -94,6 +141,9 @@ func (f *Stream) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return f, nil
 	}
 
+	if f.prompt != nil {
+		return f, f.prompt.Update(msg)
+	}
 	if f.activePane == streamPaneSend {
 		return f, f.builder.Update(msg)
 	}
@@ -105,6 +155,11 @@ func (f *Stream) View() string {
 
 	out.WriteString(callHeader(f.method))
 	out.WriteString("\n\n")
+	if f.transcript.Detail() {
+		out.WriteString(f.transcript.DetailView())
+		return out.String()
+	}
+	out.WriteString(disconnectedNotice(f.client))
 	out.WriteString(f.renderPanes())
 
 	return out.String()
@@ -113,15 +168,47 @@ func (f *Stream) View() string {
 func (f *Stream) SetSize(width, height int) {
 	f.width = width
 	f.height = height
-	if width >= 100 {
-		f.builder.SetWidth((width-2)/2 - 6)
-		return
+	f.transcript.SetSize(height-2, height-11)
+	f.builder.SetWidth(f.inputWidth())
+	if f.prompt != nil {
+		f.prompt.SetWidth(f.inputWidth())
 	}
-	f.builder.SetWidth(width - 10)
+}
+
+// inputWidth is the width available to inputs in the send pane.
+func (f *Stream) inputWidth() int {
+	if f.width >= 100 {
+		return (f.width-2)/2 - 6
+	}
+	return f.width - 10
 }
 
 func (f *Stream) AcceptsTextInput() bool {
-	return f.activePane == streamPaneSend && f.builder.AcceptsTextInput()
+	if f.prompt != nil || f.exporter != nil {
+		return true
+	}
+	if f.activePane == streamPaneRecv {
+		return f.transcript.Searching()
+	}
+	return f.builder.AcceptsTextInput()
+}
+
+func (f *Stream) Back() bool {
+	switch {
+	case f.prompt != nil:
+		f.prompt = nil
+		f.onPromptSubmit = nil
+		return true
+	case f.exporter != nil:
+		f.exporter = nil
+		return true
+	case f.activePane == streamPaneRecv && f.transcript.Back():
+		return true
+	case f.batch != nil:
+		f.finishBatch("stopped")
+		return true
+	}
+	return false
 }
 
 func (f *Stream) Cancel() {
@@ -131,13 +218,44 @@ func (f *Stream) Cancel() {
 	f.closeSend()
 }
 
+func (f *Stream) Fill(request map[string]any) {
+	f.builder.Fill(request)
+}
+
 func (f *Stream) handleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	f.notice = ""
+	if f.prompt != nil {
+		cmd, submitted := f.prompt.HandleKey(msg)
+		if submitted {
+			p, onSubmit := f.prompt, f.onPromptSubmit
+			f.prompt = nil
+			f.onPromptSubmit = nil
+			return onSubmit(p), true
+		}
+		return cmd, true
+	}
+	if f.exporter != nil {
+		if format, ok := f.exporter.HandleKey(msg); ok && f.exporter.copy(format, f.client, f.method, f.builder.Resolved(1)) {
+			f.exporter = nil
+		}
+		return nil, true
+	}
+	if f.activePane == streamPaneRecv && f.transcript.Capturing() {
+		return f.transcript.HandleKey(msg)
+	}
+
 	switch msg.String() {
+	case "ctrl+o":
+		return f.openSendFilePrompt(), true
+	case "ctrl+n":
+		return f.openRepeatPrompt(), true
+	case "ctrl+s":
+		return f.openSavePrompt(), true
 	case "ctrl+y":
 		if f.activePane == streamPaneRecv {
 			f.copyTranscript()
 		} else {
-			f.copyGRPCURLCommand()
+			f.exporter = newExportPicker()
 		}
 		return nil, true
 	case "shift+tab":
@@ -155,21 +273,8 @@ func (f *Stream) handleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
 				f.reset()
 			}
 			return nil, true
-		case "t":
-			f.timestamps = !f.timestamps
-			return nil, true
-		case "up":
-			if f.scrollIndex > 0 {
-				f.scrollIndex--
-			}
-			return nil, true
-		case "down":
-			if f.scrollIndex < f.maxScroll() {
-				f.scrollIndex++
-			}
-			return nil, true
 		}
-		return nil, false
+		return f.transcript.HandleKey(msg)
 	}
 
 	cmd, handled := f.builder.HandleKey(msg, f.sendMessage)
@@ -199,11 +304,31 @@ func (f *Stream) renderSendPane() string {
 	}
 	out.WriteString(headerStyle.Render(title))
 	out.WriteString("\n")
+	if f.prompt != nil {
+		out.WriteString(f.prompt.View())
+		return out.String()
+	}
+	if f.exporter != nil {
+		out.WriteString(f.exporter.View())
+		return out.String()
+	}
 	out.WriteString(f.builder.View("Send", f.activePane == streamPaneSend, f.sendClosed || f.closed))
 	out.WriteString("\n\n")
 	out.WriteString(labelStyle.Render("status: " + f.status()))
 	out.WriteString("\n")
-	out.WriteString(labelStyle.Render("tab/up/down: navigate • shift+tab: switch pane • ctrl+d: close send • ctrl+y: copy grpcurl"))
+	if f.notice != "" {
+		out.WriteString(labelStyle.Render(f.notice))
+		out.WriteString("\n")
+	}
+	if f.batch != nil {
+		progress := fmt.Sprintf("sending %s: %d/%d", f.batch.source, f.batch.sent, f.batch.total)
+		if f.batch.delay > 0 {
+			progress += " every " + f.batch.delay.String()
+		}
+		out.WriteString(labelStyle.Render(progress + " • esc: stop"))
+		out.WriteString("\n")
+	}
+	out.WriteString(labelStyle.Render(f.sendHelp()))
 
 	return out.String()
 }
@@ -218,14 +343,12 @@ func (f *Stream) renderReceivePane() string {
 	out.WriteString(headerStyle.Render(title))
 	out.WriteString("\n")
 
-	lines := f.visibleTranscript()
-	if len(lines) == 0 {
-		out.WriteString(labelStyle.Render("No stream events yet."))
-		out.WriteString("\n")
-	} else {
-		out.WriteString(strings.Join(f.transcriptLines(lines), "\n"))
+	if f.started || f.stats.total > 0 {
+		out.WriteString(labelStyle.Render(f.stats.View(time.Now())))
 		out.WriteString("\n")
 	}
+	out.WriteString(f.transcript.View(f.activePane == streamPaneRecv))
+	out.WriteString("\n")
 	out.WriteString("\n")
 	out.WriteString(labelStyle.Render(f.receiveHelp()))
 
@@ -233,25 +356,194 @@ func (f *Stream) renderReceivePane() string {
 }
 
 func (f *Stream) sendMessage() tea.Cmd {
+	cmd, sent := f.send(f.builder.Resolved(1))
+	if !sent && !f.sendClosed && !f.closed {
+		f.notice = "the stream isn't taking messages, try again"
+	}
+	return cmd
+}
+
+// send queues request on the stream, starting it if needed, and reports
+// whether it was queued. It never blocks: the stream stops reading requests
+// when the server ends it early or flow control holds it up, and a blocked
+// send would freeze the whole UI.
+func (f *Stream) send(request map[string]any) (tea.Cmd, bool) {
 	if f.sendClosed || f.closed {
-		return nil
+		return nil, false
 	}
 
-	var cmds []tea.Cmd
+	var cmd tea.Cmd
 	if !f.started {
-		cmds = append(cmds, f.startStream())
+		cmd = f.startStream()
 	}
 
-	request := f.builder.Value()
-	f.requests <- request
-	f.appendTranscript("> sent " + payloadPreview(request))
-	f.scrollToBottom()
+	select {
+	case f.requests <- request:
+	default:
+		return cmd, false
+	}
+	f.recordSent(request)
 
 	if !f.method.IsStreamingClient() {
 		f.closeSend()
 	}
 
-	return tea.Batch(cmds...)
+	return cmd, true
+}
+
+// canStartBatch reports whether a file or repeated send can be started.
+func (f *Stream) canStartBatch() bool {
+	return f.method.IsStreamingClient() && !f.sendClosed && !f.closed && f.batch == nil
+}
+
+func (f *Stream) openSendFilePrompt() tea.Cmd {
+	if !f.canStartBatch() {
+		return nil
+	}
+
+	f.prompt = newPrompt("Send messages from NDJSON file",
+		newPromptInput("file", "path/to/messages.ndjson", "", nil),
+		newPromptInput("delay", "delay between messages, e.g. 100ms", "0s", validateDuration),
+	)
+	f.prompt.SetWidth(f.inputWidth())
+	f.onPromptSubmit = func(p *prompt) tea.Cmd {
+		delay, _ := time.ParseDuration(p.Value(1))
+		return f.startFileSend(p.Value(0), delay)
+	}
+	return nil
+}
+
+func (f *Stream) openRepeatPrompt() tea.Cmd {
+	if !f.canStartBatch() {
+		return nil
+	}
+
+	f.prompt = newPrompt("Repeat payload ({{seq}}, {{uuid}}, {{now}} are expanded)",
+		newPromptInput("count", "number of messages", "10", validatePositiveInt),
+		newPromptInput("every", "interval, e.g. 1s", "1s", validateDuration),
+	)
+	f.prompt.SetWidth(f.inputWidth())
+	f.onPromptSubmit = func(p *prompt) tea.Cmd {
+		count, _ := strconv.Atoi(p.Value(0))
+		every, _ := time.ParseDuration(p.Value(1))
+		return f.startRepeat(count, every)
+	}
+	return nil
+}
+
+func (f *Stream) openSavePrompt() tea.Cmd {
+	if f.transcript.Len() == 0 {
+		return nil
+	}
+
+	f.prompt = newPrompt("Save transcript (NDJSON for .ndjson and .jsonl, JSON otherwise)",
+		newPromptInput("file", "path/to/transcript.json", "transcript.json", nil),
+	)
+	f.prompt.SetWidth(f.inputWidth())
+	f.onPromptSubmit = func(p *prompt) tea.Cmd {
+		f.saveTranscript(p.Value(0))
+		return nil
+	}
+	return nil
+}
+
+func (f *Stream) saveTranscript(path string) {
+	log := transcript.Log{
+		Method:  string(f.method.FullName()),
+		Target:  f.client.Target(),
+		Entries: f.transcript.Entries(),
+	}
+
+	if err := writeTranscript(path, log); err != nil {
+		f.notice = "failed to save transcript: " + err.Error()
+		return
+	}
+	f.notice = fmt.Sprintf("saved %d entries to %s", len(log.Entries), path)
+}
+
+func writeTranscript(path string, log transcript.Log) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := transcript.Write(file, transcript.FormatForPath(path), log); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (f *Stream) startFileSend(path string, delay time.Duration) tea.Cmd {
+	file, err := os.Open(path)
+	if err != nil {
+		f.record(transcript.Entry{Direction: transcript.Error, Text: err.Error()})
+		return nil
+	}
+	defer file.Close() //nolint:errcheck // read-only file
+
+	requests, err := grpc.ReadNDJSON(file)
+	if err != nil {
+		f.record(transcript.Entry{Direction: transcript.Error, Text: fmt.Sprintf("reading %s: %v", filepath.Base(path), err)})
+		return nil
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	f.batch = &sendBatch{
+		source: filepath.Base(path),
+		next:   func(seq int) map[string]any { return requests[seq-1] },
+		total:  len(requests),
+		delay:  delay,
+	}
+	return f.sendNextInBatch()
+}
+
+// startRepeat sends the current builder payload count times, expanding
+// placeholders separately for every message.
+func (f *Stream) startRepeat(count int, every time.Duration) tea.Cmd {
+	request, vars := f.builder.Value(), f.builder.vars
+	f.batch = &sendBatch{
+		source: "builder",
+		next: func(seq int) map[string]any {
+			return placeholder.ExpandText(request, placeholder.Context{Seq: seq, Vars: vars})
+		},
+		total: count,
+		delay: every,
+	}
+	return f.sendNextInBatch()
+}
+
+// sendNextInBatch sends the next batch message and schedules the one after it.
+func (f *Stream) sendNextInBatch() tea.Cmd {
+	if f.batch == nil {
+		return nil
+	}
+	if f.batch.sent == f.batch.total {
+		f.finishBatch("sent")
+		return nil
+	}
+	if f.sendClosed || f.closed {
+		f.finishBatch("stopped")
+		return nil
+	}
+
+	cmd, sent := f.send(f.batch.next(f.batch.sent + 1))
+	next := batchSendMsg{generation: f.generation, batch: f.batch}
+	if !sent {
+		return tea.Batch(cmd, tea.Tick(batchRetryDelay, func(time.Time) tea.Msg { return next }))
+	}
+
+	f.batch.sent++
+	if f.batch.sent == f.batch.total || f.batch.delay <= 0 {
+		return tea.Batch(cmd, func() tea.Msg { return next })
+	}
+	return tea.Batch(cmd, tea.Tick(f.batch.delay, func(time.Time) tea.Msg { return next }))
+}
+
+func (f *Stream) finishBatch(verb string) {
+	f.record(transcript.Entry{Direction: transcript.Info, Text: fmt.Sprintf("%s %d/%d messages from %s", verb, f.batch.sent, f.batch.total, f.batch.source)})
+	f.batch = nil
 }
 
 func (f *Stream) startStream() tea.Cmd {
@@ -271,48 +563,99 @@ func (f *Stream) startStream() tea.Cmd {
 	return tea.Batch(func() tea.Msg {
 		_ = client.InvokeStreaming(ctx, methodFullName, requests, events)
 		return streamDoneMsg{generation: generation}
-	}, f.waitForStreamEvent(generation))
+	}, f.waitForStreamEvents(generation), f.tickStats())
 }
 
-func (f *Stream) waitForStreamEvent(generation int) tea.Cmd {
+func (f *Stream) tickStats() tea.Cmd {
+	generation := f.generation
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return statsTickMsg{generation: generation}
+	})
+}
+
+// waitForStreamEvents blocks for the next stream event and then drains any
+// others already buffered, so fast streams are handled in batches rather than
+// one update per message.
+func (f *Stream) waitForStreamEvents(generation int) tea.Cmd {
 	events := f.events
 	return func() tea.Msg {
 		event, ok := <-events
 		if !ok {
 			return streamDoneMsg{generation: generation}
 		}
-		return streamEventMsg{generation: generation, event: event}
+
+		batch := []grpc.StreamEvent{event}
+		for len(batch) < maxEventBatch {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return streamEventsMsg{generation: generation, events: batch}
+				}
+				batch = append(batch, event)
+			default:
+				return streamEventsMsg{generation: generation, events: batch}
+			}
+		}
+		return streamEventsMsg{generation: generation, events: batch}
+	}
+}
+
+func (f *Stream) handleStreamEvents(events []grpc.StreamEvent) tea.Cmd {
+	for _, event := range events {
+		if !f.handleStreamEvent(event) {
+			return nil
+		}
 	}
+	return f.waitForStreamEvents(f.generation)
 }
 
-func (f *Stream) handleStreamEvent(event grpc.StreamEvent) tea.Cmd {
+// handleStreamEvent records event and reports whether more events follow.
+func (f *Stream) handleStreamEvent(event grpc.StreamEvent) bool {
 	switch event.Kind {
 	case grpc.StreamEventResponse:
-		f.recvCount++
-		f.appendTranscript(fmt.Sprintf("< recv #%d\n%s", f.recvCount, strings.TrimRight(event.Message, "\n")))
-		f.scrollToBottom()
-		return f.waitForStreamEvent(f.generation)
+		now := time.Now()
+		f.stats.Add(len(event.Message), now)
+		f.record(transcript.Entry{Time: now, Direction: transcript.Received, Message: json.RawMessage(event.Message)})
+		return true
+	case grpc.StreamEventSentHeaders:
+		f.record(transcript.Entry{Direction: transcript.SentHeaders, Metadata: event.Metadata})
+		return true
+	case grpc.StreamEventHeaders:
+		f.record(transcript.Entry{Direction: transcript.Headers, Metadata: event.Metadata})
+		return true
+	case grpc.StreamEventTrailers:
+		entry := transcript.Entry{Direction: transcript.Trailers, Metadata: event.Metadata}
+		if event.Status != nil {
+			entry.Status = &transcript.Status{Code: event.Status.Code().String(), Message: event.Status.Message()}
+		}
+		f.record(entry)
+		return true
 	case grpc.StreamEventError:
 		msg := "unknown error"
 		if event.Err != nil {
 			msg = event.Err.Error()
 		}
-		f.appendTranscript("! error: " + msg)
-		f.closed = true
-		f.sendClosed = true
-		f.scrollToBottom()
-		return nil
+		f.record(transcript.Entry{Direction: transcript.Error, Text: msg})
+		f.end()
+		return false
 	case grpc.StreamEventClosed:
-		f.appendTranscript("x closed")
-		f.closed = true
-		f.sendClosed = true
-		f.scrollToBottom()
-		return nil
+		f.record(transcript.Entry{Direction: transcript.Info, Text: "closed"})
+		f.end()
+		return false
 	default:
 		panic(fmt.Sprintf("unknown stream event: %d", event.Kind))
 	}
 }
 
+// end marks the stream as over and stops a batch still sending on it.
+func (f *Stream) end() {
+	f.closed = true
+	f.sendClosed = true
+	if f.batch != nil {
+		f.finishBatch("stopped")
+	}
+}
+
 func (f *Stream) closeSend() {
 	if !f.started || f.sendClosed {
 		return
@@ -335,10 +678,10 @@ func (f *Stream) reset() {
 	f.cancel = nil
 	f.requests = nil
 	f.events = nil
-	f.recvCount = 0
+	f.stats = streamStats{}
 	f.generation++
-	f.appendTranscript("x reset")
-	f.scrollToBottom()
+	f.batch = nil
+	f.record(transcript.Entry{Direction: transcript.Info, Text: "reset"})
 }
 
 func (f *Stream) togglePane() {
@@ -364,93 +707,40 @@ func (f *Stream) status() string {
 	}
 }
 
-func (f *Stream) receiveHelp() string {
-	parts := []string{"t: toggle timestamps", "ctrl+y: copy transcript"}
-	if f.canReset() {
-		parts = append(parts, "r: reset")
+func (f *Stream) sendHelp() string {
+	parts := []string{"tab/up/down: navigate", "shift+tab: switch pane", "ctrl+d: close send"}
+	if f.method.IsStreamingClient() {
+		parts = append(parts, "ctrl+o: send file", "ctrl+n: repeat")
 	}
+	parts = append(parts, "ctrl+y: copy as")
 	return strings.Join(parts, " • ")
 }
 
-func (f *Stream) appendTranscript(text string) {
-	f.transcript = append(f.transcript, transcriptEntry{
-		at:   time.Now(),
-		text: text,
-	})
-}
-
-func (f *Stream) visibleTranscript() []transcriptEntry {
-	if f.height <= 0 || len(f.transcript) == 0 {
-		return f.transcript
-	}
-
-	maxLines := f.height - 8
-	if maxLines < 3 {
-		maxLines = 3
-	}
-	if maxLines >= len(f.transcript) {
-		return f.transcript
-	}
-
-	start := min(f.scrollIndex, len(f.transcript)-maxLines)
-	return f.transcript[start : start+maxLines]
-}
-
-func (f *Stream) transcriptLines(entries []transcriptEntry) []string {
-	lines := make([]string, 0, len(entries))
-	for _, entry := range entries {
-		if f.timestamps {
-			lines = append(lines, entry.at.Format("15:04:05.000000000")+" "+entry.text)
-			continue
-		}
-		lines = append(lines, entry.text)
+func (f *Stream) receiveHelp() string {
+	parts := []string{f.transcript.Help(), "ctrl+y: copy transcript", "ctrl+s: save"}
+	if f.canReset() {
+		parts = append(parts, "r: reset")
 	}
-	return lines
+	return strings.Join(parts, " • ")
 }
 
-func (f *Stream) maxScroll() int {
-	maxLines := f.height - 8
-	if maxLines < 3 {
-		maxLines = 3
+// record appends an entry to the transcript.
+func (f *Stream) record(entry transcript.Entry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
 	}
-	if len(f.transcript) <= maxLines {
-		return 0
-	}
-	return len(f.transcript) - maxLines
+	f.transcript.Append(newTranscriptEntry(entry, f.stats.total))
 }
 
-func (f *Stream) scrollToBottom() {
-	f.scrollIndex = f.maxScroll()
-}
-
-func (f *Stream) copyGRPCURLCommand() {
-	command, err := f.client.GRPCURLCommand(string(f.method.FullName()), f.builder.Value())
+func (f *Stream) recordSent(request map[string]any) {
+	data, err := json.Marshal(request)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "error building grpcurl command: %v\n", err)
+		f.record(transcript.Entry{Direction: transcript.Error, Text: "failed to marshal request: " + err.Error()})
 		return
 	}
-	if err := clipboard.WriteAll(command); err != nil {
-		fmt.Fprintf(os.Stderr, "error writing to clipboard: %v\n", err)
-	}
+	f.record(transcript.Entry{Direction: transcript.Sent, Message: data})
 }
 
 func (f *Stream) copyTranscript() {
-	if err := clipboard.WriteAll(strings.Join(f.transcriptLines(f.transcript), "\n")); err != nil {
-		fmt.Fprintf(os.Stderr, "error writing to clipboard: %v\n", err)
-	}
-}
-
-func payloadPreview(payload map[string]any) string {
-	const maxPayloadPreviewLen = 120
-
-	data, err := json.Marshal(payload)
-	if err != nil {
-		return "<failed to marshal request>"
-	}
-
-	preview := string(data)
-	if len(preview) <= maxPayloadPreviewLen {
-		return preview
-	}
-	return preview[:maxPayloadPreviewLen-3] + "..."
+	copyToClipboard(f.transcript.PlainText())
 }
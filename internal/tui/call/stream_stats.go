@@ -0,0 +1,75 @@
+package call
+
+import (
+	"fmt"
+	"time"
+)
+
+// streamStats tracks how fast responses arrive on a stream.
+type streamStats struct {
+	total int
+	bytes int64
+	last  time.Time
+
+	// received since the last tick, turned into rates on every tick
+	tickAt    time.Time
+	tickCount int
+	tickBytes int64
+	msgRate   float64
+	byteRate  float64
+}
+
+// Add counts a received message of size bytes.
+func (s *streamStats) Add(size int, at time.Time) {
+	if s.tickAt.IsZero() {
+		s.tickAt = at
+	}
+	s.total++
+	s.bytes += int64(size)
+	s.last = at
+	s.tickCount++
+	s.tickBytes += int64(size)
+}
+
+// Tick updates the rates from the messages received since the previous tick.
+func (s *streamStats) Tick(now time.Time) {
+	if s.tickAt.IsZero() {
+		return
+	}
+	elapsed := now.Sub(s.tickAt).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	s.msgRate = float64(s.tickCount) / elapsed
+	s.byteRate = float64(s.tickBytes) / elapsed
+	s.tickAt = now
+	s.tickCount = 0
+	s.tickBytes = 0
+}
+
+func (s *streamStats) View(now time.Time) string {
+	if s.total == 0 {
+		return "received 0"
+	}
+	return fmt.Sprintf("received %d (%s) • %.0f msg/s • %s/s • last %s ago",
+		s.total,
+		formatBytes(float64(s.bytes)),
+		s.msgRate,
+		formatBytes(s.byteRate),
+		now.Sub(s.last).Truncate(100*time.Millisecond),
+	)
+}
+
+func formatBytes(n float64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%.0f B", n)
+	}
+	suffixes := "KMGT"
+	i := -1
+	for n >= unit && i < len(suffixes)-1 {
+		n /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %ciB", n, suffixes[i])
+}
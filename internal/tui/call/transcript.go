@@ -0,0 +1,592 @@
+package call
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/transcript"
+)
+
+// transcriptView holds stream transcript entries and the state of the pane
+// showing them: a cursor over the entries that pass the direction filter and
+// search, and an optional full-screen view of the selected entry. It is shared
+// by the stream screen and the read-only transcript viewer.
+type transcriptView struct {
+	entries entryRing
+	// shown holds the absolute indexes of the entries that pass the filter and search
+	shown  []int
+	cursor int
+	offset int
+	// follow keeps the cursor on the newest entry as entries arrive
+	follow bool
+	// paused freezes the pane; new entries are held in pending until resumed
+	paused  bool
+	pending entryRing
+	// lost counts entries that overflowed pending while paused
+	lost int
+	// rows is the number of lines available to entries
+	rows       int
+	height     int
+	timestamps bool
+
+	filter    directionFilter
+	search    textinput.Model
+	searching bool
+
+	detail       *transcriptEntry
+	detailOffset int
+}
+
+type directionFilter int
+
+const (
+	filterAll directionFilter = iota
+	filterSent
+	filterReceived
+	filterErrors
+)
+
+func (d directionFilter) String() string {
+	switch d {
+	case filterSent:
+		return "sent"
+	case filterReceived:
+		return "received"
+	case filterErrors:
+		return "errors"
+	default:
+		return "all"
+	}
+}
+
+func (d directionFilter) matches(direction transcript.Direction) bool {
+	switch d {
+	case filterSent:
+		return direction == transcript.Sent || direction == transcript.SentHeaders
+	case filterReceived:
+		return direction == transcript.Received || direction == transcript.Headers || direction == transcript.Trailers
+	case filterErrors:
+		return direction == transcript.Error
+	default:
+		return true
+	}
+}
+
+// newTranscriptView keeps at most limit entries, dropping the oldest, or every
+// entry when limit is 0.
+func newTranscriptView(limit int) transcriptView {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "search"
+	return transcriptView{
+		entries: entryRing{limit: limit},
+		pending: entryRing{limit: limit},
+		follow:  true,
+		search:  search,
+	}
+}
+
+type transcriptEntry struct {
+	transcript.Entry
+	// text is the rendered form shown in the pane, rendered once on arrival
+	text  string
+	lines int
+}
+
+func newTranscriptEntry(entry transcript.Entry, recvCount int) transcriptEntry {
+	text := entryText(entry, recvCount)
+	return transcriptEntry{Entry: entry, text: text, lines: strings.Count(text, "\n") + 1}
+}
+
+func entryText(entry transcript.Entry, recvCount int) string {
+	switch entry.Direction {
+	case transcript.Sent:
+		return "> sent " + previewJSON(entry.Message)
+	case transcript.Received:
+		return fmt.Sprintf("< recv #%d\n%s", recvCount, indentJSON(entry.Message))
+	case transcript.SentHeaders:
+		return "> headers" + metadataText(entry.Metadata)
+	case transcript.Headers:
+		return "< headers" + metadataText(entry.Metadata)
+	case transcript.Trailers:
+		text := "< trailers"
+		if entry.Status != nil {
+			text += " " + entry.Status.Code
+		}
+		return text + metadataText(entry.Metadata)
+	case transcript.Error:
+		return "! error: " + entry.Text
+	default:
+		return "x " + entry.Text
+	}
+}
+
+func metadataText(md map[string][]string) string {
+	keys := make([]string, 0, len(md))
+	for key := range md {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var out strings.Builder
+	for _, key := range keys {
+		for _, value := range md[key] {
+			out.WriteString("\n  " + key + ": " + value)
+		}
+	}
+	return out.String()
+}
+
+func indentJSON(data json.RawMessage) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return out.String()
+}
+
+func previewJSON(data json.RawMessage) string {
+	const maxPayloadPreviewLen = 120
+
+	var out bytes.Buffer
+	if err := json.Compact(&out, data); err != nil {
+		return string(data)
+	}
+	preview := out.String()
+	if len(preview) <= maxPayloadPreviewLen {
+		return preview
+	}
+	return preview[:maxPayloadPreviewLen-3] + "..."
+}
+
+func (t *transcriptView) Append(entry transcriptEntry) {
+	if t.paused {
+		t.pending.Push(entry)
+		return
+	}
+	if t.entries.Push(entry) {
+		t.dropEvicted()
+	}
+	if t.matches(entry) {
+		t.shown = append(t.shown, t.entries.End()-1)
+		if t.follow {
+			t.cursor = len(t.shown) - 1
+		}
+		t.scrollToCursor()
+	}
+}
+
+// dropEvicted forgets shown entries that the ring buffer has overwritten.
+func (t *transcriptView) dropEvicted() {
+	n := 0
+	for n < len(t.shown) && t.shown[n] < t.entries.First() {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	t.shown = t.shown[n:]
+	t.cursor = max(t.cursor-n, 0)
+	t.offset = max(t.offset-n, 0)
+}
+
+// Entries returns the kept entries, including any held while paused, without
+// their rendered text.
+func (t *transcriptView) Entries() []transcript.Entry {
+	entries := make([]transcript.Entry, 0, t.Len())
+	for _, ring := range []*entryRing{&t.entries, &t.pending} {
+		for i := ring.First(); i < ring.End(); i++ {
+			entries = append(entries, ring.At(i).Entry)
+		}
+	}
+	return entries
+}
+
+func (t *transcriptView) Len() int {
+	return t.entries.Len() + t.pending.Len()
+}
+
+// SetSize sets the height available to the entry view and how many lines of
+// entries fit in the pane.
+func (t *transcriptView) SetSize(height, rows int) {
+	t.height = height
+	t.rows = max(rows, 3)
+	t.scrollToCursor()
+}
+
+// Capturing reports whether keys should go to the transcript before the
+// screen's own bindings, while searching or viewing an entry.
+func (t *transcriptView) Capturing() bool {
+	return t.searching || t.detail != nil
+}
+
+func (t *transcriptView) Searching() bool {
+	return t.searching
+}
+
+// Back closes the entry view or clears the search. It reports false when
+// there was nothing to close.
+func (t *transcriptView) Back() bool {
+	switch {
+	case t.detail != nil:
+		t.detail = nil
+		return true
+	case t.searching || t.search.Value() != "":
+		t.searching = false
+		t.search.Blur()
+		t.search.SetValue("")
+		t.refilter()
+		return true
+	}
+	return false
+}
+
+// HandleKey handles the transcript key bindings and reports whether msg was used.
+func (t *transcriptView) HandleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if t.detail != nil {
+		switch msg.String() {
+		case "up":
+			t.detailOffset = max(t.detailOffset-1, 0)
+		case "down":
+			t.detailOffset = min(t.detailOffset+1, t.maxDetailOffset())
+		case "c":
+			copyToClipboard(entryCopyText(*t.detail))
+		case "enter":
+			t.detail = nil
+		}
+		return nil, true
+	}
+
+	if t.searching {
+		switch msg.String() {
+		case "enter", "up", "down":
+			t.searching = false
+			t.search.Blur()
+			return nil, true
+		}
+		var cmd tea.Cmd
+		t.search, cmd = t.search.Update(msg)
+		t.refilter()
+		return cmd, true
+	}
+
+	switch msg.String() {
+	case "up":
+		t.moveCursor(-1)
+	case "down":
+		t.moveCursor(1)
+	case "pgup":
+		t.moveCursor(-max(t.rows/2, 1))
+	case "pgdown":
+		t.moveCursor(max(t.rows/2, 1))
+	case "t":
+		t.timestamps = !t.timestamps
+	case "p":
+		t.togglePause()
+	case "f":
+		t.filter = (t.filter + 1) % (filterErrors + 1)
+		t.refilter()
+	case "/":
+		t.searching = true
+		return t.search.Focus(), true
+	case "enter":
+		if entry, ok := t.selected(); ok {
+			t.detail = &entry
+			t.detailOffset = 0
+		}
+	case "c":
+		if entry, ok := t.selected(); ok {
+			copyToClipboard(entryCopyText(entry))
+		}
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// View renders the visible entries, marking the cursor when focused.
+func (t *transcriptView) View(focused bool) string {
+	var out strings.Builder
+
+	if state := t.stateLine(); state != "" {
+		out.WriteString(state)
+		out.WriteString("\n")
+	}
+
+	if t.entries.Len() == 0 {
+		out.WriteString(labelStyle.Render("No stream events yet."))
+		return out.String()
+	}
+	if len(t.shown) == 0 {
+		out.WriteString(labelStyle.Render("No matching entries."))
+		return out.String()
+	}
+
+	// only the entries that fit are rendered, however long the transcript is
+	budget := t.lineBudget()
+	var lines []string
+	for i := t.offset; i < len(t.shown) && (budget > 0 || t.rows <= 0); i++ {
+		entry := t.entries.At(t.shown[i])
+		line := t.line(entry)
+		if t.rows > 0 && entry.lines > budget {
+			line = strings.Join(strings.SplitN(line, "\n", budget+1)[:budget], "\n")
+		}
+		budget -= entry.lines
+		if focused {
+			// keep multi-line entries aligned behind the cursor marker
+			marker := "  "
+			if i == t.cursor {
+				marker = selectedStyle.Render("▌") + " "
+			}
+			line = marker + strings.ReplaceAll(line, "\n", "\n  ")
+		}
+		lines = append(lines, line)
+	}
+	out.WriteString(strings.Join(lines, "\n"))
+	return out.String()
+}
+
+// DetailView renders the selected entry full screen.
+func (t *transcriptView) DetailView() string {
+	var out strings.Builder
+
+	entry := *t.detail
+	out.WriteString(headerStyle.Render(fmt.Sprintf("%s • %s", entry.Direction, entry.Time.Format("2006-01-02 15:04:05.000000000"))))
+	out.WriteString("\n")
+
+	lines := strings.Split(entryCopyText(entry), "\n")
+	end := min(t.detailOffset+t.detailRows(), len(lines))
+	out.WriteString(strings.Join(lines[t.detailOffset:end], "\n"))
+	out.WriteString("\n\n")
+	out.WriteString(labelStyle.Render("up/down: scroll • c: copy • esc: close"))
+	return out.String()
+}
+
+func (t *transcriptView) Detail() bool {
+	return t.detail != nil
+}
+
+func (t *transcriptView) Help() string {
+	return "up/down: select • enter: open • c: copy entry • /: search • f: filter • p: pause • t: toggle timestamps"
+}
+
+// PlainText renders every entry for copying.
+func (t *transcriptView) PlainText() string {
+	lines := make([]string, 0, t.entries.Len())
+	for i := t.entries.First(); i < t.entries.End(); i++ {
+		lines = append(lines, t.line(t.entries.At(i)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (t *transcriptView) stateLine() string {
+	var parts []string
+	if t.paused {
+		parts = append(parts, fmt.Sprintf("paused • %d new messages • p: resume", t.pending.End()))
+	}
+	if dropped := t.entries.First() + t.lost; dropped > 0 {
+		parts = append(parts, fmt.Sprintf("%d oldest dropped", dropped))
+	}
+	if t.filter != filterAll {
+		parts = append(parts, "filter: "+t.filter.String())
+	}
+	if t.searching || t.search.Value() != "" {
+		parts = append(parts, t.search.View())
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	parts = append(parts, fmt.Sprintf("%d/%d entries", len(t.shown), t.entries.Len()))
+	return labelStyle.Render(strings.Join(parts, " • "))
+}
+
+func (t *transcriptView) line(entry transcriptEntry) string {
+	if t.timestamps {
+		return entry.Time.Format("15:04:05.000000000") + " " + entry.text
+	}
+	return entry.text
+}
+
+func (t *transcriptView) matches(entry transcriptEntry) bool {
+	if !t.filter.matches(entry.Direction) {
+		return false
+	}
+	query := strings.ToLower(strings.TrimSpace(t.search.Value()))
+	return query == "" || strings.Contains(strings.ToLower(entry.text), query)
+}
+
+// refilter rebuilds the shown entries, keeping the cursor on the selected
+// entry when it still matches.
+func (t *transcriptView) refilter() {
+	selected := -1
+	if t.cursor < len(t.shown) {
+		selected = t.shown[t.cursor]
+	}
+
+	t.shown = t.shown[:0]
+	t.cursor = 0
+	for i := t.entries.First(); i < t.entries.End(); i++ {
+		if !t.matches(t.entries.At(i)) {
+			continue
+		}
+		if i <= selected {
+			t.cursor = len(t.shown)
+		}
+		t.shown = append(t.shown, i)
+	}
+	if t.follow {
+		t.cursor = max(len(t.shown)-1, 0)
+	}
+	t.offset = 0
+	t.scrollToCursor()
+}
+
+func (t *transcriptView) moveCursor(delta int) {
+	if len(t.shown) == 0 {
+		return
+	}
+	t.cursor = min(max(t.cursor+delta, 0), len(t.shown)-1)
+	t.follow = t.cursor == len(t.shown)-1
+	t.scrollToCursor()
+}
+
+// togglePause freezes or resumes the pane. Resuming appends the entries held
+// while paused and jumps to the newest one.
+func (t *transcriptView) togglePause() {
+	if !t.paused {
+		t.paused = true
+		return
+	}
+
+	t.paused = false
+	t.follow = true
+	pending := t.pending
+	t.pending = entryRing{limit: pending.limit}
+	t.lost += pending.First()
+	for i := pending.First(); i < pending.End(); i++ {
+		t.Append(pending.At(i))
+	}
+	t.cursor = max(len(t.shown)-1, 0)
+	t.scrollToCursor()
+}
+
+// scrollToCursor moves the offset so the cursor entry ends within the line budget.
+func (t *transcriptView) scrollToCursor() {
+	if t.rows <= 0 || len(t.shown) == 0 {
+		return
+	}
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+		return
+	}
+
+	used := 0
+	for i := t.offset; i <= t.cursor; i++ {
+		used += t.entries.At(t.shown[i]).lines
+	}
+	budget := t.lineBudget()
+	for used > budget && t.offset < t.cursor {
+		used -= t.entries.At(t.shown[t.offset]).lines
+		t.offset++
+	}
+}
+
+// lineBudget is the number of lines left for entries below the state line.
+func (t *transcriptView) lineBudget() int {
+	if t.stateLine() != "" {
+		return max(t.rows-1, 1)
+	}
+	return t.rows
+}
+
+func (t *transcriptView) selected() (transcriptEntry, bool) {
+	if t.cursor >= len(t.shown) {
+		return transcriptEntry{}, false
+	}
+	return t.entries.At(t.shown[t.cursor]), true
+}
+
+func (t *transcriptView) detailRows() int {
+	return max(t.height-4, 3)
+}
+
+func (t *transcriptView) maxDetailOffset() int {
+	lines := strings.Count(entryCopyText(*t.detail), "\n") + 1
+	return max(lines-t.detailRows(), 0)
+}
+
+// entryCopyText is the full form of an entry: the indented message for sent
+// and received entries, and the status, metadata or text otherwise.
+func entryCopyText(entry transcriptEntry) string {
+	switch entry.Direction {
+	case transcript.Sent, transcript.Received:
+		return indentJSON(entry.Message)
+	case transcript.SentHeaders, transcript.Headers:
+		return strings.TrimPrefix(metadataText(entry.Metadata), "\n")
+	case transcript.Trailers:
+		var out strings.Builder
+		if entry.Status != nil {
+			out.WriteString("status: " + entry.Status.Code)
+			if entry.Status.Message != "" {
+				out.WriteString(" " + entry.Status.Message)
+			}
+		}
+		out.WriteString(metadataText(entry.Metadata))
+		return strings.TrimPrefix(out.String(), "\n")
+	default:
+		return entry.Text
+	}
+}
+
+func copyToClipboard(text string) {
+	if err := clipboard.WriteAll(text); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing to clipboard: %v\n", err)
+	}
+}
+
+// entryRing is a ring buffer of transcript entries addressed by absolute
+// index, counting entries that have already been overwritten.
+type entryRing struct {
+	// limit caps the buffer size, 0 keeps every entry
+	limit   int
+	buf     []transcriptEntry
+	start   int
+	dropped int
+}
+
+// Push appends entry and reports whether the oldest entry was overwritten.
+func (r *entryRing) Push(entry transcriptEntry) bool {
+	if r.limit <= 0 || len(r.buf) < r.limit {
+		r.buf = append(r.buf, entry)
+		return false
+	}
+	r.buf[r.start] = entry
+	r.start = (r.start + 1) % len(r.buf)
+	r.dropped++
+	return true
+}
+
+func (r *entryRing) Len() int {
+	return len(r.buf)
+}
+
+// First is the absolute index of the oldest kept entry.
+func (r *entryRing) First() int {
+	return r.dropped
+}
+
+// End is one past the absolute index of the newest entry.
+func (r *entryRing) End() int {
+	return r.dropped + len(r.buf)
+}
+
+func (r *entryRing) At(i int) transcriptEntry {
+	return r.buf[(r.start+i-r.dropped)%len(r.buf)]
+}
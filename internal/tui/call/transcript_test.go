@@ -0,0 +1,362 @@
+package call
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/transcript"
+)
+
+// textEntry is an entry rendered as text.
+func textEntry(direction transcript.Direction, text string) transcriptEntry {
+	return transcriptEntry{Entry: transcript.Entry{Direction: direction, Text: text}, text: text, lines: 1}
+}
+
+// numbered returns n received entries named from first on.
+func numbered(first, n int) []transcriptEntry {
+	entries := make([]transcriptEntry, n)
+	for i := range entries {
+		entries[i] = textEntry(transcript.Received, fmt.Sprint(first+i))
+	}
+	return entries
+}
+
+func texts(from, to int) []string {
+	var out []string
+	for i := from; i < to; i++ {
+		out = append(out, fmt.Sprint(i))
+	}
+	return out
+}
+
+// shownTexts returns the text of the entries the pane lists.
+func shownTexts(v *transcriptView) []string {
+	var out []string
+	for _, i := range v.shown {
+		out = append(out, v.entries.At(i).text)
+	}
+	return out
+}
+
+func selectedText(v *transcriptView) string {
+	entry, ok := v.selected()
+	if !ok {
+		return ""
+	}
+	return entry.text
+}
+
+// press sends keys to the pane, typing any key that isn't a named one.
+func press(v *transcriptView, keys ...string) {
+	named := map[string]tea.KeyType{"up": tea.KeyUp, "down": tea.KeyDown, "enter": tea.KeyEnter}
+	for _, key := range keys {
+		if typ, ok := named[key]; ok {
+			v.HandleKey(tea.KeyMsg{Type: typ})
+			continue
+		}
+		for _, r := range key {
+			v.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+}
+
+func newTestTranscript(limit int, entries ...transcriptEntry) *transcriptView {
+	v := newTranscriptView(limit)
+	v.SetSize(40, 20)
+	for _, entry := range entries {
+		v.Append(entry)
+	}
+	return &v
+}
+
+func TestEntryRing(t *testing.T) {
+	tests := []struct {
+		limit, pushes int
+		wantFirst     int
+		wantOverwrite int
+	}{
+		{limit: 0, pushes: 5, wantFirst: 0, wantOverwrite: 0},
+		{limit: 3, pushes: 2, wantFirst: 0, wantOverwrite: 0},
+		{limit: 3, pushes: 3, wantFirst: 0, wantOverwrite: 0},
+		{limit: 3, pushes: 4, wantFirst: 1, wantOverwrite: 1},
+		{limit: 3, pushes: 8, wantFirst: 5, wantOverwrite: 5},
+		{limit: 1, pushes: 3, wantFirst: 2, wantOverwrite: 2},
+	}
+
+	for _, tt := range tests {
+		r := entryRing{limit: tt.limit}
+		overwrites := 0
+		for _, entry := range numbered(0, tt.pushes) {
+			if r.Push(entry) {
+				overwrites++
+			}
+		}
+
+		var got []string
+		for i := r.First(); i < r.End(); i++ {
+			got = append(got, r.At(i).text)
+		}
+		want := texts(tt.wantFirst, tt.pushes)
+		if r.First() != tt.wantFirst || r.End() != tt.pushes || r.Len() != len(want) {
+			t.Errorf("limit %d after %d pushes: First, End, Len = %d, %d, %d, want %d, %d, %d",
+				tt.limit, tt.pushes, r.First(), r.End(), r.Len(), tt.wantFirst, tt.pushes, len(want))
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("limit %d after %d pushes: entries = %v, want %v", tt.limit, tt.pushes, got, want)
+		}
+		if overwrites != tt.wantOverwrite {
+			t.Errorf("limit %d after %d pushes: %d overwrites, want %d", tt.limit, tt.pushes, overwrites, tt.wantOverwrite)
+		}
+	}
+}
+
+func TestTranscriptEvictsSelectedEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		// up is how far the cursor moves up before more entries arrive
+		up, more     int
+		wantSelected string
+	}{
+		{name: "selected entry kept", up: 1, more: 1, wantSelected: "1"},
+		{name: "selected entry evicted", up: 2, more: 1, wantSelected: "1"},
+		{name: "every shown entry evicted", up: 2, more: 4, wantSelected: "4"},
+	}
+
+	for _, tt := range tests {
+		v := newTestTranscript(3, numbered(0, 3)...)
+		for range tt.up {
+			press(v, "up")
+		}
+		opened := selectedText(v)
+		press(v, "enter")
+
+		for _, entry := range numbered(3, tt.more) {
+			v.Append(entry)
+		}
+		if got, want := shownTexts(v), texts(tt.more, 3+tt.more); !slices.Equal(got, want) {
+			t.Errorf("%s: shown = %v, want %v", tt.name, got, want)
+		}
+		if got := selectedText(v); got != tt.wantSelected {
+			t.Errorf("%s: selected %q, want %q", tt.name, got, tt.wantSelected)
+		}
+		// the open entry is a copy and outlives its eviction
+		if !v.Detail() || !strings.Contains(v.DetailView(), opened) {
+			t.Errorf("%s: open entry %q lost:\n%s", tt.name, opened, v.DetailView())
+		}
+		press(v, "enter")
+		if view := v.View(true); !strings.Contains(view, "▌") {
+			t.Errorf("%s: no entry selected:\n%s", tt.name, view)
+		}
+	}
+}
+
+func TestTranscriptEvictsSearchMatches(t *testing.T) {
+	v := newTestTranscript(3)
+	press(v, "/", "a", "enter")
+
+	steps := []struct {
+		text string
+		want []string
+	}{
+		{text: "a0", want: []string{"a0"}},
+		{text: "b1", want: []string{"a0"}},
+		{text: "a2", want: []string{"a0", "a2"}},
+		{text: "b3", want: []string{"a2"}},
+		{text: "a4", want: []string{"a2", "a4"}},
+		{text: "b5", want: []string{"a4"}},
+		{text: "b6", want: []string{"a4"}},
+		{text: "b7", want: nil},
+	}
+	for _, step := range steps {
+		v.Append(textEntry(transcript.Received, step.text))
+		if got := shownTexts(v); !slices.Equal(got, step.want) {
+			t.Fatalf("after %s: shown = %v, want %v", step.text, got, step.want)
+		}
+		if v.cursor != max(len(step.want)-1, 0) {
+			t.Fatalf("after %s: cursor = %d, want the newest match", step.text, v.cursor)
+		}
+	}
+	if view := v.View(true); !strings.Contains(view, "No matching entries.") {
+		t.Fatalf("View with every match evicted:\n%s", view)
+	}
+}
+
+func TestTranscriptPause(t *testing.T) {
+	tests := []struct {
+		name                 string
+		limit, before, while int
+		want                 []string
+		wantDropped          int
+	}{
+		{name: "unbounded", limit: 0, before: 2, while: 3, want: texts(0, 5)},
+		{name: "fits", limit: 5, before: 2, while: 3, want: texts(0, 5)},
+		{name: "evicted on resume", limit: 3, before: 2, while: 2, want: texts(1, 4), wantDropped: 1},
+		{name: "overflowed while paused", limit: 3, before: 2, while: 5, want: texts(4, 7), wantDropped: 4},
+	}
+
+	for _, tt := range tests {
+		v := newTestTranscript(tt.limit, numbered(0, tt.before)...)
+		press(v, "up", "p")
+
+		for _, entry := range numbered(tt.before, tt.while) {
+			v.Append(entry)
+		}
+		if got, want := shownTexts(v), texts(0, tt.before); !slices.Equal(got, want) {
+			t.Errorf("%s: shown while paused = %v, want %v", tt.name, got, want)
+		}
+		if got := selectedText(v); got != "0" {
+			t.Errorf("%s: selected %q while paused, want 0", tt.name, got)
+		}
+		if state := v.stateLine(); !strings.Contains(state, fmt.Sprintf("%d new messages", tt.while)) {
+			t.Errorf("%s: state while paused = %q", tt.name, state)
+		}
+		kept := tt.while
+		if tt.limit > 0 {
+			kept = min(kept, tt.limit)
+		}
+		var held []string
+		for _, entry := range v.Entries() {
+			held = append(held, entry.Text)
+		}
+		if want := append(texts(0, tt.before), texts(tt.before+tt.while-kept, tt.before+tt.while)...); !slices.Equal(held, want) {
+			t.Errorf("%s: Entries while paused = %v, want %v", tt.name, held, want)
+		}
+
+		press(v, "p")
+		if got := shownTexts(v); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: shown after resume = %v, want %v", tt.name, got, tt.want)
+		}
+		var entries []string
+		for _, entry := range v.Entries() {
+			entries = append(entries, entry.Text)
+		}
+		if !slices.Equal(entries, tt.want) {
+			t.Errorf("%s: Entries after resume = %v, want %v", tt.name, entries, tt.want)
+		}
+		if got, want := selectedText(v), tt.want[len(tt.want)-1]; got != want {
+			t.Errorf("%s: selected %q after resume, want the newest %q", tt.name, got, want)
+		}
+		dropped := fmt.Sprintf("%d oldest dropped", tt.wantDropped)
+		if state := v.stateLine(); strings.Contains(state, dropped) != (tt.wantDropped > 0) || strings.Contains(state, "paused") {
+			t.Errorf("%s: state after resume = %q, want %q", tt.name, state, dropped)
+		}
+
+		// entries arrive as usual once resumed
+		v.Append(textEntry(transcript.Received, "next"))
+		if got := selectedText(v); got != "next" {
+			t.Errorf("%s: selected %q after a new entry, want next", tt.name, got)
+		}
+	}
+}
+
+// mixedEntries has an entry of every direction.
+func mixedEntries() []transcriptEntry {
+	return []transcriptEntry{
+		textEntry(transcript.SentHeaders, "> headers"),
+		textEntry(transcript.Sent, "> sent alpha"),
+		textEntry(transcript.Headers, "< headers"),
+		textEntry(transcript.Received, "< recv alpha"),
+		textEntry(transcript.Received, "< recv beta"),
+		textEntry(transcript.Trailers, "< trailers OK"),
+		textEntry(transcript.Error, "! error: alpha"),
+	}
+}
+
+func TestTranscriptFilterAndSearch(t *testing.T) {
+	tests := []struct {
+		filters int
+		search  string
+		want    []string
+	}{
+		{want: []string{"> headers", "> sent alpha", "< headers", "< recv alpha", "< recv beta", "< trailers OK", "! error: alpha"}},
+		{filters: 1, want: []string{"> headers", "> sent alpha"}},
+		{filters: 2, want: []string{"< headers", "< recv alpha", "< recv beta", "< trailers OK"}},
+		{filters: 3, want: []string{"! error: alpha"}},
+		{filters: 4, want: []string{"> headers", "> sent alpha", "< headers", "< recv alpha", "< recv beta", "< trailers OK", "! error: alpha"}},
+		{search: "alpha", want: []string{"> sent alpha", "< recv alpha", "! error: alpha"}},
+		{filters: 2, search: "ALPHA", want: []string{"< recv alpha"}},
+		{filters: 2, search: "recv", want: []string{"< recv alpha", "< recv beta"}},
+		{filters: 1, search: "headers", want: []string{"> headers"}},
+		{filters: 1, search: "beta", want: nil},
+	}
+
+	for _, tt := range tests {
+		v := newTestTranscript(0, mixedEntries()...)
+		for range tt.filters {
+			press(v, "f")
+		}
+		if tt.search != "" {
+			press(v, "/", tt.search, "enter")
+		}
+
+		name := fmt.Sprintf("filter %s, search %q", v.filter, tt.search)
+		if got := shownTexts(v); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: shown = %v, want %v", name, got, tt.want)
+		}
+		view := v.View(true)
+		if len(tt.want) == 0 && !strings.Contains(view, "No matching entries.") {
+			t.Errorf("%s: View with no matches:\n%s", name, view)
+		}
+		if state := fmt.Sprintf("%d/7 entries", len(tt.want)); (tt.filters%4 != 0 || tt.search != "") && !strings.Contains(view, state) {
+			t.Errorf("%s: View is missing %q:\n%s", name, state, view)
+		}
+	}
+}
+
+func TestTranscriptSearchNavigation(t *testing.T) {
+	v := newTestTranscript(0, mixedEntries()...)
+	v.Append(textEntry(transcript.Sent, "> sent recv"))
+	v.Append(textEntry(transcript.Received, "< recv gamma"))
+	press(v, "f", "f", "/", "recv", "enter")
+
+	// the sent entry matches the search but not the filter
+	if got, want := shownTexts(v), []string{"< recv alpha", "< recv beta", "< recv gamma"}; !slices.Equal(got, want) {
+		t.Fatalf("shown = %v, want %v", got, want)
+	}
+
+	steps := []struct {
+		key  string
+		want string
+	}{
+		{want: "< recv gamma"},
+		{key: "up", want: "< recv beta"},
+		{key: "up", want: "< recv alpha"},
+		{key: "up", want: "< recv alpha"},
+		{key: "down", want: "< recv beta"},
+		{key: "down", want: "< recv gamma"},
+		{key: "down", want: "< recv gamma"},
+		{key: "up", want: "< recv beta"},
+	}
+	for i, step := range steps {
+		if step.key != "" {
+			press(v, step.key)
+		}
+		if got := selectedText(v); got != step.want {
+			t.Fatalf("step %d (%s): selected %q, want %q", i, step.key, got, step.want)
+		}
+	}
+
+	// entries that don't match stay out and don't move the cursor
+	v.Append(textEntry(transcript.Received, "< recv delta"))
+	v.Append(textEntry(transcript.Error, "! error: recv"))
+	if got := selectedText(v); got != "< recv beta" {
+		t.Fatalf("selected %q after new entries, want < recv beta", got)
+	}
+	press(v, "down", "down")
+	if got := selectedText(v); got != "< recv delta" {
+		t.Fatalf("selected %q at the end, want < recv delta", got)
+	}
+
+	// clearing the search keeps the selection within the filter
+	press(v, "up", "up")
+	v.Back()
+	if got, want := shownTexts(v), []string{"< headers", "< recv alpha", "< recv beta", "< trailers OK", "< recv gamma", "< recv delta"}; !slices.Equal(got, want) {
+		t.Fatalf("shown after clearing the search = %v, want %v", got, want)
+	}
+	if got := selectedText(v); got != "< recv beta" {
+		t.Fatalf("selected %q after clearing the search, want < recv beta", got)
+	}
+}
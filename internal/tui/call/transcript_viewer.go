@@ -0,0 +1,89 @@
+package call
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/transcript"
+)
+
+// TranscriptViewer shows a saved stream transcript read-only.
+type TranscriptViewer struct {
+	name       string
+	log        transcript.Log
+	transcript transcriptView
+}
+
+// NewTranscriptViewer shows log, which was loaded from the file called name.
+func NewTranscriptViewer(name string, log transcript.Log) *TranscriptViewer {
+	v := &TranscriptViewer{name: name, log: log, transcript: newTranscriptView(0)}
+
+	recvCount := 0
+	for _, entry := range log.Entries {
+		if entry.Direction == transcript.Received {
+			recvCount++
+		}
+		v.transcript.Append(newTranscriptEntry(entry, recvCount))
+	}
+	return v
+}
+
+func (v *TranscriptViewer) Init() tea.Cmd {
+	return nil
+}
+
+func (v *TranscriptViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.transcript.SetSize(msg.Height, msg.Height-6)
+	case tea.KeyMsg:
+		key := msg.String()
+		// q closes an open entry, but is typed into the search
+		closing := key == "esc" || key == "ctrl+c" || key == "q" && v.transcript.Detail()
+		if v.transcript.Capturing() && !closing {
+			cmd, _ := v.transcript.HandleKey(msg)
+			return v, cmd
+		}
+
+		switch key {
+		case "esc", "q":
+			if v.transcript.Back() {
+				return v, nil
+			}
+			return v, tea.Quit
+		case "ctrl+c":
+			return v, tea.Quit
+		case "ctrl+y":
+			copyToClipboard(v.transcript.PlainText())
+		default:
+			cmd, _ := v.transcript.HandleKey(msg)
+			return v, cmd
+		}
+	}
+	return v, nil
+}
+
+func (v *TranscriptViewer) View() string {
+	var out strings.Builder
+
+	title := v.name
+	if v.log.Method != "" {
+		title = v.log.Method + " (" + v.name + ")"
+	}
+	out.WriteString(headerStyle.Render(title))
+	out.WriteString("\n")
+	if v.log.Target != "" {
+		out.WriteString(labelStyle.Render("recorded against " + v.log.Target))
+		out.WriteString("\n")
+	}
+	out.WriteString("\n")
+	if v.transcript.Detail() {
+		out.WriteString(v.transcript.DetailView())
+		return out.String()
+	}
+	out.WriteString(v.transcript.View(true))
+	out.WriteString("\n\n")
+	out.WriteString(labelStyle.Render(v.transcript.Help() + " • ctrl+y: copy transcript • q: quit"))
+
+	return out.String()
+}
@@ -0,0 +1,38 @@
+package call
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/transcript"
+)
+
+func TestTranscriptViewerQuitKey(t *testing.T) {
+	v := NewTranscriptViewer("chat.ndjson", transcript.Log{Entries: []transcript.Entry{
+		{Time: time.Now(), Direction: transcript.Received, Message: json.RawMessage(`{"message":"hi"}`)},
+	}})
+	v.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !v.transcript.Detail() {
+		t.Fatal("enter did not open the entry")
+	}
+
+	// q steps back out of the entry before it quits
+	if _, cmd := v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd != nil {
+		t.Fatal("q quit with an entry open")
+	}
+	if v.transcript.Detail() {
+		t.Fatal("q did not close the entry")
+	}
+
+	_, cmd := v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil {
+		t.Fatal("q did not quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("q did not quit")
+	}
+}
@@ -21,6 +21,7 @@ const (
 	unaryStateInput unaryState = iota
 	unaryStateCalling
 	unaryStateResult
+	unaryStateBench
 )
 
 type Unary struct {
@@ -28,9 +29,16 @@ type Unary struct {
 	builder *Builder
 	client  *grpc.Client
 	state   unaryState
+	width   int
 
 	response    string
 	responseErr error
+
+	// prompt collects the bench parameters
+	prompt *prompt
+	bench  *benchRun
+	// exporter picks the format the request is copied in
+	exporter *exportPicker
 }
 
 type rpcResultMsg struct {
@@ -38,10 +46,12 @@ type rpcResultMsg struct {
 	err      error
 }
 
-func NewUnary(method protoreflect.MethodDescriptor, client *grpc.Client) *Unary {
+func NewUnary(method protoreflect.MethodDescriptor, client *grpc.Client, opts Options) *Unary {
+	builder := NewBuilder(method.Input())
+	builder.SetVars(opts.Vars)
 	return &Unary{
 		method:  method,
-		builder: NewBuilder(method.Input()),
+		builder: builder,
 		client:  client,
 	}
 }
@@ -57,16 +67,39 @@ func (f *Unary) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		f.response = msg.response
 		f.responseErr = msg.err
 		return f, nil
+	case benchPreparedMsg, benchDoneMsg, benchTickMsg:
+		return f, f.handleBenchMsg(msg)
 	case tea.KeyMsg:
+		if f.exporter != nil {
+			if format, ok := f.exporter.HandleKey(msg); ok && f.exporter.copy(format, f.client, f.method, f.builder.Resolved(1)) {
+				f.exporter = nil
+			}
+			return f, nil
+		}
+		if f.prompt != nil {
+			cmd, submitted := f.prompt.HandleKey(msg)
+			if submitted {
+				p := f.prompt
+				f.prompt = nil
+				return f, f.startBench(p)
+			}
+			return f, cmd
+		}
+
 		switch f.state {
 		case unaryStateResult:
 			return f, f.handleResultKey(msg)
 		case unaryStateCalling:
 			return f, nil
+		case unaryStateBench:
+			return f, f.handleBenchKey(msg)
 		case unaryStateInput:
-			if msg.String() == "ctrl+y" {
-				f.copyGRPCURLCommand()
+			switch msg.String() {
+			case "ctrl+y":
+				f.exporter = newExportPicker()
 				return f, nil
+			case "ctrl+b":
+				return f, f.openBenchPrompt()
 			}
 			cmd, handled := f.builder.HandleKey(msg, func() tea.Cmd {
 				f.state = unaryStateCalling
@@ -80,6 +113,9 @@ func (f *Unary) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if f.prompt != nil {
+		return f, f.prompt.Update(msg)
+	}
 	if f.state == unaryStateInput {
 		return f, f.builder.Update(msg)
 	}
@@ -91,6 +127,16 @@ func (f *Unary) View() string {
 
 	out.WriteString(callHeader(f.method))
 	out.WriteString("\n\n")
+	out.WriteString(disconnectedNotice(f.client))
+
+	if f.prompt != nil {
+		out.WriteString(f.prompt.View())
+		return out.String()
+	}
+	if f.exporter != nil {
+		out.WriteString(f.exporter.View())
+		return out.String()
+	}
 
 	switch f.state {
 	case unaryStateCalling:
@@ -107,11 +153,13 @@ func (f *Unary) View() string {
 			out.WriteString(f.response)
 		}
 		out.WriteString("\n\n")
-		out.WriteString(labelStyle.Render("esc: back • r: resubmit • y: copy response • ctrl+y: copy grpcurl • q: quit"))
+		out.WriteString(labelStyle.Render("esc: back • r: resubmit • y: copy response • ctrl+y: copy as • q: quit"))
 	case unaryStateInput:
 		out.WriteString(f.builder.View("Submit", true, false))
 		out.WriteString("\n\n")
-		out.WriteString(labelStyle.Render("up/down/tab: navigate • left/right: options • ctrl+y: copy grpcurl"))
+		out.WriteString(labelStyle.Render("up/down/tab: navigate • left/right: options • ctrl+y: copy as • ctrl+b: bench"))
+	case unaryStateBench:
+		out.WriteString(f.benchView())
 	default:
 		panic(fmt.Sprintf("unknown unary state: %d", f.state))
 	}
@@ -120,14 +168,38 @@ func (f *Unary) View() string {
 }
 
 func (f *Unary) SetSize(width, _ int) {
+	f.width = width
 	f.builder.SetWidth(width - 10)
+	if f.prompt != nil {
+		f.prompt.SetWidth(width - 20)
+	}
 }
 
 func (f *Unary) AcceptsTextInput() bool {
-	return f.state == unaryStateInput && f.builder.AcceptsTextInput()
+	return f.prompt != nil || f.exporter != nil || f.state == unaryStateInput && f.builder.AcceptsTextInput()
 }
 
-func (f *Unary) Cancel() {}
+func (f *Unary) Back() bool {
+	if f.prompt != nil {
+		f.prompt = nil
+		return true
+	}
+	if f.exporter != nil {
+		f.exporter = nil
+		return true
+	}
+	return f.stopBench()
+}
+
+func (f *Unary) Cancel() {
+	if f.bench != nil {
+		f.bench.cancel()
+	}
+}
+
+func (f *Unary) Fill(request map[string]any) {
+	f.builder.Fill(request)
+}
 
 func (f *Unary) handleResultKey(msg tea.KeyMsg) tea.Cmd {
 	switch msg.String() {
@@ -143,7 +215,7 @@ func (f *Unary) handleResultKey(msg tea.KeyMsg) tea.Cmd {
 			fmt.Fprintf(os.Stderr, "error writing to clipboard: %v\n", err)
 		}
 	case "ctrl+y":
-		f.copyGRPCURLCommand()
+		f.exporter = newExportPicker()
 	case "q":
 		return tea.Quit
 	}
@@ -152,7 +224,7 @@ func (f *Unary) handleResultKey(msg tea.KeyMsg) tea.Cmd {
 
 func (f *Unary) invokeRPC() tea.Cmd {
 	methodFullName := string(f.method.FullName())
-	request := f.builder.Value()
+	request := f.builder.Resolved(1)
 	client := f.client
 
 	return func() tea.Msg {
@@ -163,14 +235,3 @@ func (f *Unary) invokeRPC() tea.Cmd {
 		return rpcResultMsg{response: response, err: err}
 	}
 }
-
-func (f *Unary) copyGRPCURLCommand() {
-	command, err := f.client.GRPCURLCommand(string(f.method.FullName()), f.builder.Value())
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "error building grpcurl command: %v\n", err)
-		return
-	}
-	if err := clipboard.WriteAll(command); err != nil {
-		fmt.Fprintf(os.Stderr, "error writing to clipboard: %v\n", err)
-	}
-}
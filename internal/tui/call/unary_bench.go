@@ -0,0 +1,193 @@
+package call
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnvbn/grpcexp/internal/bench"
+)
+
+const benchTickInterval = 200 * time.Millisecond
+
+// benchRun is a load test of the built request started from the Unary screen.
+type benchRun struct {
+	opts    bench.Options
+	runner  *bench.Runner
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started time.Time
+
+	report *bench.Report
+	err    error
+}
+
+type benchPreparedMsg struct {
+	run  *benchRun
+	call bench.Call
+	err  error
+}
+
+type benchDoneMsg struct {
+	run    *benchRun
+	report bench.Report
+}
+
+type benchTickMsg struct {
+	run *benchRun
+}
+
+func (f *Unary) openBenchPrompt() tea.Cmd {
+	f.prompt = newPrompt("Bench (leave count empty to run for the duration)",
+		newPromptInput("count", "number of calls", strconv.Itoa(bench.DefaultCount), validateUint),
+		newPromptInput("concurrency", "calls in flight at once", strconv.Itoa(bench.DefaultConcurrency), validatePositiveInt),
+		newPromptInput("duration", "run for, e.g. 10s", "", validateDuration),
+		newPromptInput("rate", "maximum calls per second, 0 for no limit", "0", validateFloat),
+	)
+	f.prompt.SetWidth(f.width - 20)
+	return nil
+}
+
+func (f *Unary) startBench(p *prompt) tea.Cmd {
+	var opts bench.Options
+	// the prompt has validated every value
+	opts.Count, _ = strconv.Atoi(p.Value(0))
+	opts.Concurrency, _ = strconv.Atoi(p.Value(1))
+	opts.Duration, _ = time.ParseDuration(p.Value(2))
+	opts.Rate, _ = strconv.ParseFloat(p.Value(3), 64)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &benchRun{opts: opts, ctx: ctx, cancel: cancel}
+	f.bench = run
+	f.state = unaryStateBench
+
+	methodFullName := string(f.method.FullName())
+	request := f.builder.Resolved(1)
+	client := f.client
+	return func() tea.Msg {
+		call, err := client.PrepareUnary(ctx, methodFullName, request)
+		return benchPreparedMsg{run: run, call: bench.Call(call), err: err}
+	}
+}
+
+func (f *Unary) handleBenchMsg(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case benchPreparedMsg:
+		run := msg.run
+		if run != f.bench {
+			return nil
+		}
+		if msg.err != nil {
+			run.err = msg.err
+			run.cancel()
+			return nil
+		}
+
+		run.runner = bench.NewRunner(msg.call, run.opts)
+		run.started = time.Now()
+		return tea.Batch(
+			func() tea.Msg {
+				return benchDoneMsg{run: run, report: run.runner.Run(run.ctx)}
+			},
+			benchTick(run),
+		)
+	case benchDoneMsg:
+		msg.run.cancel()
+		msg.run.report = &msg.report
+	case benchTickMsg:
+		if msg.run == f.bench && msg.run.report == nil {
+			return benchTick(msg.run)
+		}
+	}
+	return nil
+}
+
+func benchTick(run *benchRun) tea.Cmd {
+	return tea.Tick(benchTickInterval, func(time.Time) tea.Msg {
+		return benchTickMsg{run: run}
+	})
+}
+
+func (f *Unary) handleBenchKey(msg tea.KeyMsg) tea.Cmd {
+	run := f.bench
+	if run.report == nil && run.err == nil {
+		return nil
+	}
+
+	switch msg.String() {
+	case "r":
+		return f.openBenchPrompt()
+	case "y":
+		content := run.report.String()
+		if run.err != nil {
+			content = run.err.Error()
+		}
+		copyToClipboard(content)
+	case "q":
+		return tea.Quit
+	}
+	return nil
+}
+
+// stopBench cancels a running bench or leaves a finished one. It reports
+// whether there was a bench to stop.
+func (f *Unary) stopBench() bool {
+	if f.bench == nil {
+		return false
+	}
+	if f.bench.report == nil && f.bench.err == nil {
+		f.bench.cancel()
+		return true
+	}
+	f.bench = nil
+	f.state = unaryStateInput
+	f.builder.ResetToSubmit()
+	return true
+}
+
+func (f *Unary) benchView() string {
+	var out strings.Builder
+	run := f.bench
+
+	out.WriteString(headerStyle.Render("Bench"))
+	out.WriteString("\n\n")
+	switch {
+	case run.err != nil:
+		out.WriteString(labelStyle.Render(run.err.Error()))
+		out.WriteString("\n\n")
+		out.WriteString(labelStyle.Render("esc: back • r: run again • y: copy error • q: quit"))
+	case run.report != nil:
+		out.WriteString(run.report.String())
+		out.WriteString("\n")
+		out.WriteString(labelStyle.Render("esc: back • r: run again • y: copy report • q: quit"))
+	case run.runner == nil:
+		out.WriteString(labelStyle.Render("Connecting..."))
+	default:
+		out.WriteString(benchProgress(run))
+		out.WriteString("\n\n")
+		out.WriteString(labelStyle.Render("esc: stop"))
+	}
+	return out.String()
+}
+
+func benchProgress(run *benchRun) string {
+	opts := run.runner.Options()
+	elapsed := time.Since(run.started).Round(100 * time.Millisecond)
+
+	progress := fmt.Sprintf("%d calls", run.runner.Completed())
+	if opts.Count > 0 {
+		progress = fmt.Sprintf("%d/%d calls", run.runner.Completed(), opts.Count)
+	}
+	progress += " • " + elapsed.String()
+	if opts.Duration > 0 {
+		progress += "/" + opts.Duration.String()
+	}
+	progress += fmt.Sprintf(" • concurrency %d", opts.Concurrency)
+	if opts.Rate > 0 {
+		progress += fmt.Sprintf(" • %g calls/s max", opts.Rate)
+	}
+	return labelStyle.Render("Running... " + progress)
+}
@@ -0,0 +1,254 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/prnvbn/grpcexp/cmd/testserver/server"
+	"github.com/prnvbn/grpcexp/internal/grpc"
+	"github.com/prnvbn/grpcexp/internal/tui/call"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	harnessWidth  = 120
+	harnessHeight = 40
+	// waitTimeout bounds how long a harness waits for the view to change.
+	waitTimeout = 5 * time.Second
+)
+
+// harness drives a Model against the test server running in-process the way
+// the bubbletea runtime would: commands run in their own goroutines and the
+// messages they return are fed back through Update on the test goroutine.
+type harness struct {
+	t        *testing.T
+	model    tea.Model
+	msgs     chan tea.Msg
+	done     chan struct{}
+	requests *requestLog
+	quit     bool
+}
+
+// harnessOptions adjust the client and server a harness runs against.
+type harnessOptions struct {
+	call call.Options
+	// config, if set, changes the client's config before it connects.
+	config func(config *grpc.Config)
+	// server are added to the test server's options.
+	server []grpclib.ServerOption
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	return newHarnessWithOptions(t, harnessOptions{call: call.Options{TranscriptLimit: 100}})
+}
+
+func newHarnessWithOptions(t *testing.T, opts harnessOptions) *harness {
+	t.Helper()
+
+	requests := &requestLog{}
+	dialer := server.Start(t, append([]grpclib.ServerOption{
+		grpclib.ChainUnaryInterceptor(requests.unary),
+		grpclib.ChainStreamInterceptor(requests.stream),
+	}, opts.server...)...)
+
+	config := grpc.Config{
+		Target:    "bufnet",
+		Dialer:    dialer,
+		Creds:     insecure.NewCredentials(),
+		UserAgent: "grpcexp/test",
+	}
+	if opts.config != nil {
+		opts.config(&config)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+	defer cancel()
+	client, err := grpc.NewClient(ctx, config)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	model, err := NewModel(client, opts.call)
+	if err != nil {
+		_ = client.Close()
+		t.Fatalf("NewModel returned error: %v", err)
+	}
+
+	h := &harness{
+		t:        t,
+		model:    model,
+		msgs:     make(chan tea.Msg, 64),
+		done:     make(chan struct{}),
+		requests: requests,
+	}
+	t.Cleanup(func() {
+		close(h.done)
+		_ = client.Close()
+	})
+
+	h.run(h.model.Init())
+	h.send(tea.WindowSizeMsg{Width: harnessWidth, Height: harnessHeight})
+	return h
+}
+
+// send passes msg to the model and runs the command it returns.
+func (h *harness) send(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case tea.QuitMsg:
+		h.quit = true
+		return
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			h.run(cmd)
+		}
+		return
+	}
+
+	var cmd tea.Cmd
+	h.model, cmd = h.model.Update(msg)
+	h.run(cmd)
+}
+
+func (h *harness) run(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	go func() {
+		msg := cmd()
+		if msg == nil {
+			return
+		}
+		select {
+		case h.msgs <- msg:
+		case <-h.done:
+		}
+	}()
+}
+
+// press sends special keys such as tea.KeyEnter or tea.KeyCtrlP.
+func (h *harness) press(keys ...tea.KeyType) {
+	for _, key := range keys {
+		h.send(tea.KeyMsg{Type: key})
+	}
+}
+
+// typeText sends text one key at a time.
+func (h *harness) typeText(text string) {
+	for _, r := range text {
+		h.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// open jumps to a method or type through the command palette.
+func (h *harness) open(symbol string) {
+	h.press(tea.KeyCtrlP)
+	h.typeText(symbol)
+	h.press(tea.KeyEnter)
+}
+
+// view returns the rendered view without styling.
+func (h *harness) view() string {
+	return ansi.Strip(h.model.View())
+}
+
+// waitFor processes messages until the view contains every one of texts.
+func (h *harness) waitFor(texts ...string) {
+	h.t.Helper()
+	h.waitUntil(strings.Join(texts, ", "), func(view string) bool {
+		for _, text := range texts {
+			if !strings.Contains(view, text) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (h *harness) waitUntil(desc string, cond func(view string) bool) {
+	h.t.Helper()
+
+	deadline := time.After(waitTimeout)
+	for !cond(h.view()) {
+		select {
+		case msg := <-h.msgs:
+			h.send(msg)
+		case <-deadline:
+			h.t.Fatalf("timed out waiting for %s, view:\n%s", desc, h.view())
+		}
+	}
+}
+
+// waitForQuit processes messages until the model quits.
+func (h *harness) waitForQuit() {
+	h.t.Helper()
+	h.waitUntil("quit", func(string) bool { return h.quit })
+}
+
+// invokedRequest is a request message received by the test server.
+type invokedRequest struct {
+	Method  string
+	Message map[string]any
+}
+
+// requestLog records the requests the test server receives, leaving out
+// server reflection.
+type requestLog struct {
+	mu       sync.Mutex
+	requests []invokedRequest
+}
+
+func (l *requestLog) record(method string, msg any) {
+	if strings.HasPrefix(method, "/grpc.reflection.") {
+		return
+	}
+
+	message := map[string]any{}
+	if m, ok := msg.(proto.Message); ok {
+		if data, err := protojson.Marshal(m); err == nil {
+			_ = json.Unmarshal(data, &message)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.requests = append(l.requests, invokedRequest{Method: method, Message: message})
+}
+
+func (l *requestLog) All() []invokedRequest {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]invokedRequest(nil), l.requests...)
+}
+
+func (l *requestLog) unary(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+	l.record(info.FullMethod, req)
+	return handler(ctx, req)
+}
+
+func (l *requestLog) stream(srv any, ss grpclib.ServerStream, info *grpclib.StreamServerInfo, handler grpclib.StreamHandler) error {
+	return handler(srv, &recordingStream{ServerStream: ss, method: info.FullMethod, log: l})
+}
+
+type recordingStream struct {
+	grpclib.ServerStream
+	method string
+	log    *requestLog
+}
+
+func (s *recordingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.log.record(s.method, m)
+	return nil
+}
@@ -36,6 +36,8 @@ func NewMethodsList(serviceName string, methods []protoreflect.MethodDescriptor)
 	l.AdditionalShortHelpKeys = func() []key.Binding {
 		return []key.Binding{
 			key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "navigate")),
+			key.NewBinding(key.WithKeys("ctrl+p"), key.WithHelp("ctrl+p", "search")),
+			key.NewBinding(key.WithKeys("ctrl+t"), key.WithHelp("ctrl+t", "input type")),
 		}
 	}
 
@@ -79,6 +81,16 @@ func (m *MethodsList) View() string {
 	return m.list.View()
 }
 
+// Select moves the cursor to the item with the given full name.
+func (m *MethodsList) Select(name string) {
+	for i, it := range m.list.Items() {
+		if item, ok := it.(methodItem); ok && string(item.method.FullName()) == name {
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
 func (m *MethodsList) SelectedItem() (methodItem, bool) {
 	item, ok := m.list.SelectedItem().(methodItem)
 	return item, ok
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	paletteKindStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("243")).Width(8)
+	paletteMatchStyle = lipgloss.NewStyle().Underline(true)
+)
+
+// Palette fuzzy-searches every method, message and enum in the descriptor source.
+type Palette struct {
+	input   textinput.Model
+	symbols []protoreflect.Descriptor
+	names   []string
+	matches fuzzy.Matches
+	cursor  int
+	width   int
+	height  int
+}
+
+func NewPalette(symbols []protoreflect.Descriptor) Palette {
+	ti := textinput.New()
+	ti.Placeholder = "Search methods, messages and enums..."
+	ti.Prompt = "> "
+	ti.Focus()
+
+	names := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		names[i] = string(symbol.FullName())
+	}
+
+	p := Palette{
+		input:   ti,
+		symbols: symbols,
+		names:   names,
+	}
+	p.filter()
+	return p
+}
+
+func (p *Palette) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (p *Palette) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.input.Width = width - 4
+}
+
+func (p *Palette) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "up", "shift+tab":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+			return nil
+		case "down", "tab":
+			if p.cursor < len(p.matches)-1 {
+				p.cursor++
+			}
+			return nil
+		}
+	}
+
+	query := p.input.Value()
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != query {
+		p.filter()
+	}
+	return cmd
+}
+
+// Selected returns the highlighted symbol.
+func (p *Palette) Selected() (protoreflect.Descriptor, bool) {
+	if p.cursor >= len(p.matches) {
+		return nil, false
+	}
+	return p.symbols[p.matches[p.cursor].Index], true
+}
+
+func (p *Palette) View() string {
+	var out strings.Builder
+
+	out.WriteString(p.input.View())
+	out.WriteString("\n\n")
+
+	if len(p.matches) == 0 {
+		out.WriteString(statusTextStyle.Render("  no matches"))
+		out.WriteString("\n")
+	}
+
+	visible := max(p.height-4, 1)
+	start := max(p.cursor-visible+1, 0)
+	end := min(start+visible, len(p.matches))
+	for i := start; i < end; i++ {
+		match := p.matches[i]
+		name := highlightMatch(match.Str, match.MatchedIndexes)
+		if i == p.cursor {
+			name = selectedStyle.Render(match.Str)
+			out.WriteString("> ")
+		} else {
+			out.WriteString("  ")
+		}
+		out.WriteString(paletteKindStyle.Render(symbolKind(p.symbols[match.Index])))
+		out.WriteString(name)
+		out.WriteString("\n")
+	}
+
+	out.WriteString("\n")
+	out.WriteString(statusTextStyle.Render("↑/↓: select • enter: open • esc: close"))
+	return out.String()
+}
+
+func (p *Palette) filter() {
+	p.cursor = 0
+	query := strings.TrimSpace(p.input.Value())
+	if query == "" {
+		p.matches = make(fuzzy.Matches, len(p.names))
+		for i, name := range p.names {
+			p.matches[i] = fuzzy.Match{Str: name, Index: i}
+		}
+		return
+	}
+	p.matches = fuzzy.Find(query, p.names)
+}
+
+func highlightMatch(s string, indexes []int) string {
+	if len(indexes) == 0 {
+		return s
+	}
+
+	matched := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		matched[i] = true
+	}
+
+	var out strings.Builder
+	for i, r := range s {
+		if matched[i] {
+			out.WriteString(paletteMatchStyle.Render(string(r)))
+			continue
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+func symbolKind(d protoreflect.Descriptor) string {
+	switch d.(type) {
+	case protoreflect.MethodDescriptor:
+		return "method"
+	case protoreflect.MessageDescriptor:
+		return "message"
+	case protoreflect.EnumDescriptor:
+		return "enum"
+	default:
+		return ""
+	}
+}
@@ -42,6 +42,7 @@ func NewServicesList(services []string) ServicesList {
 	l.AdditionalShortHelpKeys = func() []key.Binding {
 		return []key.Binding{
 			key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "navigate")),
+			key.NewBinding(key.WithKeys("ctrl+p"), key.WithHelp("ctrl+p", "search")),
 		}
 	}
 
@@ -84,6 +85,16 @@ func (s *ServicesList) View() string {
 	return s.list.View()
 }
 
+// Select moves the cursor to the item with the given full name.
+func (s *ServicesList) Select(name string) {
+	for i, it := range s.list.Items() {
+		if item, ok := it.(svcItem); ok && item.name == name {
+			s.list.Select(i)
+			return
+		}
+	}
+}
+
 func (s *ServicesList) SelectedItem() (svcItem, bool) {
 	item, ok := s.list.SelectedItem().(svcItem)
 	return item, ok
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/prnvbn/grpcexp/internal/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+const reconnectTimeout = 10 * time.Second
+
+var (
+	statusTextStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	statusReadyStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("42"))
+	statusBusyStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
+	statusErrorStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+)
+
+// StatusBar shows the live connectivity state of the client connection.
+type StatusBar struct {
+	client       *grpc.Client
+	state        connectivity.State
+	reconnecting bool
+	err          error
+}
+
+type connStateMsg struct {
+	state connectivity.State
+}
+
+type reconnectedMsg struct {
+	err error
+}
+
+func NewStatusBar(client *grpc.Client) StatusBar {
+	return StatusBar{
+		client: client,
+		state:  client.State(),
+	}
+}
+
+func (s *StatusBar) Init() tea.Cmd {
+	return s.watch()
+}
+
+// Update handles connectivity messages. It reports whether the message was
+// consumed and whether descriptors were refreshed by a reconnect.
+func (s *StatusBar) Update(msg tea.Msg) (cmd tea.Cmd, handled bool, refreshed bool) {
+	switch msg := msg.(type) {
+	case connStateMsg:
+		s.state = msg.state
+		return s.watch(), true, false
+	case reconnectedMsg:
+		s.reconnecting = false
+		s.err = msg.err
+		return nil, true, msg.err == nil
+	}
+	return nil, false, false
+}
+
+// Reconnect forces a reconnect and re-fetches reflection in the background.
+func (s *StatusBar) Reconnect() tea.Cmd {
+	if s.reconnecting {
+		return nil
+	}
+	s.reconnecting = true
+	s.err = nil
+
+	client := s.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), reconnectTimeout)
+		defer cancel()
+		return reconnectedMsg{err: client.Reconnect(ctx)}
+	}
+}
+
+func (s *StatusBar) View() string {
+	state := s.state.String()
+	var rendered string
+	switch {
+	case !s.client.Connected():
+		rendered = statusBusyStyle.Render("● OFFLINE")
+	case s.state == connectivity.Ready:
+		rendered = statusReadyStyle.Render("● " + state)
+	case s.state == connectivity.Connecting, s.state == connectivity.Idle:
+		rendered = statusBusyStyle.Render("● " + state)
+	default:
+		rendered = statusErrorStyle.Render("● " + state)
+	}
+
+	text := " " + s.client.Target()
+	switch {
+	case s.reconnecting:
+		text += " • reconnecting..."
+	case s.err != nil:
+		text += " • " + s.err.Error()
+	case !s.client.Connected():
+		if err := s.client.DialError(); err != nil {
+			text += " • " + err.Error()
+		}
+		text += " • connects on first call • ctrl+r: connect now"
+	default:
+		text += " • ctrl+r: reconnect"
+	}
+
+	return rendered + statusTextStyle.Render(text)
+}
+
+func (s *StatusBar) watch() tea.Cmd {
+	client := s.client
+	state := s.state
+	return func() tea.Msg {
+		client.WaitForStateChange(context.Background(), state)
+		return connStateMsg{state: client.State()}
+	}
+}
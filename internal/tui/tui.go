@@ -5,8 +5,10 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/prnvbn/grpcexp/internal/grpc"
 	"github.com/prnvbn/grpcexp/internal/tui/call"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 var _ tea.Model = &Model{}
@@ -17,6 +19,7 @@ const (
 	screenServices screenState = iota
 	screenMethods
 	screenCallMethod
+	screenType
 )
 
 type Model struct {
@@ -25,32 +28,94 @@ type Model struct {
 	servicesList   ServicesList
 	methodsList    *MethodsList
 	callMethodForm call.Screen
+	typeBrowser    *TypeBrowser
+	statusBar      StatusBar
 
-	grpcClient *grpc.Client
-	width      int
-	height     int
+	// palette is shown over the current screen while open
+	palette *Palette
+	// typeReturnState is the screen to go back to from the type browser
+	typeReturnState screenState
+	// start is the method opened when the program starts, if any
+	start *openMethodMsg
+
+	// descriptorsChanged is closed when the descriptors the services list was
+	// made from change
+	descriptorsChanged <-chan struct{}
+
+	grpcClient  *grpc.Client
+	callOptions call.Options
+	width       int
+	height      int
 }
 
-func NewModel(grpcClient *grpc.Client) (Model, error) {
+type descriptorsChangedMsg struct{}
+
+func NewModel(grpcClient *grpc.Client, callOptions call.Options) (Model, error) {
+	// taken before listing so a change in between isn't missed
+	changed := grpcClient.DescriptorsChanged()
 	services, err := grpcClient.ListServices()
 	if err != nil {
 		return Model{}, err
 	}
 
 	return Model{
-		state:        screenServices,
-		servicesList: NewServicesList(services),
-		grpcClient:   grpcClient,
+		state:              screenServices,
+		servicesList:       NewServicesList(services),
+		statusBar:          NewStatusBar(grpcClient),
+		descriptorsChanged: changed,
+		grpcClient:         grpcClient,
+		callOptions:        callOptions,
 	}, nil
 }
 
+// Open makes the model start on the call screen of method, with the builder
+// filled from request.
+func (m *Model) Open(method protoreflect.MethodDescriptor, request map[string]any) {
+	m.start = &openMethodMsg{method: method, request: request}
+}
+
 func (m Model) Init() tea.Cmd {
-	return nil
+	cmds := []tea.Cmd{m.statusBar.Init(), m.watchDescriptors()}
+	if m.start != nil {
+		start := *m.start
+		cmds = append(cmds, func() tea.Msg { return start })
+	}
+	return tea.Batch(cmds...)
+}
+
+// watchDescriptors waits for the descriptors to change, as they do when
+// reflection refreshed in the background finds services added or removed.
+func (m *Model) watchDescriptors() tea.Cmd {
+	changed := m.descriptorsChanged
+	return func() tea.Msg {
+		<-changed
+		return descriptorsChangedMsg{}
+	}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if cmd, handled, refreshed := m.statusBar.Update(msg); handled {
+		if refreshed {
+			m.refreshServices()
+		}
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
+	case descriptorsChangedMsg:
+		m.descriptorsChanged = m.grpcClient.DescriptorsChanged()
+		m.refreshServices()
+		return m, m.watchDescriptors()
+	case openMethodMsg:
+		cmd := m.jumpTo(msg.method)
+		if msg.request != nil && m.callMethodForm != nil {
+			m.callMethodForm.Fill(msg.request)
+		}
+		return m, cmd
 	case tea.KeyMsg:
+		if m.palette != nil {
+			return m.handlePaletteKey(msg)
+		}
 		model, cmd, done := m.handleKey(msg)
 		if done {
 			return model, cmd
@@ -59,11 +124,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.resize(msg)
 	}
 
+	if m.palette != nil {
+		return m, tea.Batch(m.palette.Update(msg), m.forwardToScreen(msg))
+	}
 	return m, m.forwardToScreen(msg)
 }
 
 func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 	switch msg.String() {
+	case "ctrl+p":
+		return *m, m.openPalette(), true
+	case "ctrl+t":
+		if m.state == screenMethods && m.methodsList != nil {
+			if md, ok := m.methodsList.SelectedItem(); ok {
+				m.openType(md.method.Input())
+				return *m, nil, true
+			}
+		}
+		return *m, nil, false
 	case "q":
 		if m.state == screenCallMethod && m.callMethodForm != nil && m.callMethodForm.AcceptsTextInput() {
 			return *m, nil, false
@@ -71,6 +149,8 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		fallthrough
 	case "ctrl+c":
 		return *m, tea.Quit, true
+	case "ctrl+r":
+		return *m, m.statusBar.Reconnect(), true
 	case "esc":
 		model, cmd := m.goBack()
 		return model, cmd, true
@@ -91,11 +171,21 @@ func (m *Model) goBack() (tea.Model, tea.Cmd) {
 		return *m, nil
 	case screenCallMethod:
 		if m.callMethodForm != nil {
+			if m.callMethodForm.Back() {
+				return *m, nil
+			}
 			m.callMethodForm.Cancel()
 		}
 		m.state = screenMethods
 		m.callMethodForm = nil
 		return *m, nil
+	case screenType:
+		if m.typeBrowser != nil && m.typeBrowser.Back() {
+			return *m, nil
+		}
+		m.state = m.typeReturnState
+		m.typeBrowser = nil
+		return *m, nil
 	default:
 		panic(fmt.Sprintf("unknown state - non exhaustive switch for go back: %d", m.state))
 	}
@@ -110,16 +200,10 @@ func (m *Model) drillDown() (tea.Model, tea.Cmd, bool) {
 			return *m, tea.Quit, true
 		}
 
-		methods, err := m.grpcClient.ListMethods(svc.name)
-		if err != nil {
+		if err := m.openService(svc.name); err != nil {
 			fmt.Fprintf(os.Stderr, "error listing methods: %v\n", err)
 			return *m, tea.Quit, true
 		}
-
-		methodsList := NewMethodsList(svc.name, methods)
-		methodsList.SetSize(m.width, m.height)
-		m.methodsList = &methodsList
-		m.state = screenMethods
 		return *m, nil, true
 	case screenMethods:
 		md, ok := m.methodsList.SelectedItem()
@@ -128,28 +212,146 @@ func (m *Model) drillDown() (tea.Model, tea.Cmd, bool) {
 			return *m, tea.Quit, true
 		}
 
-		methodDetails := call.NewScreen(md.method, m.grpcClient)
-		methodDetails.SetSize(m.width, m.height)
-		m.callMethodForm = methodDetails
-		m.state = screenCallMethod
-		return *m, m.callMethodForm.Init(), true
-	case screenCallMethod:
+		return *m, m.openMethod(md.method), true
+	case screenCallMethod, screenType:
 		return *m, nil, false
 	default:
 		panic(fmt.Sprintf("unknown state - non exhaustive switch for drill down: %d", m.state))
 	}
 }
 
+func (m *Model) openService(name string) error {
+	methods, err := m.grpcClient.ListMethods(name)
+	if err != nil {
+		return err
+	}
+
+	methodsList := NewMethodsList(name, methods)
+	methodsList.SetSize(m.width, m.screenHeight())
+	m.methodsList = &methodsList
+	m.state = screenMethods
+	return nil
+}
+
+func (m *Model) openMethod(method protoreflect.MethodDescriptor) tea.Cmd {
+	if m.callMethodForm != nil {
+		m.callMethodForm.Cancel()
+	}
+
+	methodDetails := call.NewScreen(method, m.grpcClient, m.callOptions)
+	methodDetails.SetSize(m.width, m.screenHeight())
+	m.callMethodForm = methodDetails
+	m.state = screenCallMethod
+	return m.callMethodForm.Init()
+}
+
+func (m *Model) openPalette() tea.Cmd {
+	symbols, err := m.grpcClient.ListSymbols()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing symbols: %v\n", err)
+		return nil
+	}
+
+	palette := NewPalette(symbols)
+	palette.SetSize(m.width, m.screenHeight())
+	m.palette = &palette
+	return m.palette.Init()
+}
+
+func (m *Model) handlePaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return *m, tea.Quit
+	case "esc", "ctrl+p":
+		m.palette = nil
+		return *m, nil
+	case "enter":
+		symbol, ok := m.palette.Selected()
+		if !ok {
+			return *m, nil
+		}
+		m.palette = nil
+		return *m, m.jumpTo(symbol)
+	}
+	return *m, m.palette.Update(msg)
+}
+
+// jumpTo opens the call screen for a method or the type browser for a message or enum.
+func (m *Model) jumpTo(symbol protoreflect.Descriptor) tea.Cmd {
+	switch d := symbol.(type) {
+	case protoreflect.MethodDescriptor:
+		svc := string(d.Parent().FullName())
+		m.servicesList.Select(svc)
+		if err := m.openService(svc); err != nil {
+			fmt.Fprintf(os.Stderr, "error listing methods: %v\n", err)
+			return nil
+		}
+		m.methodsList.Select(string(d.FullName()))
+		return m.openMethod(d)
+	default:
+		m.openType(d)
+		return nil
+	}
+}
+
+func (m *Model) openType(d protoreflect.Descriptor) {
+	symbols, err := m.grpcClient.ListSymbols()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing symbols: %v\n", err)
+	}
+
+	var methods []protoreflect.MethodDescriptor
+	for _, symbol := range symbols {
+		if method, ok := symbol.(protoreflect.MethodDescriptor); ok {
+			methods = append(methods, method)
+		}
+	}
+
+	if m.state != screenType {
+		m.typeReturnState = m.state
+	}
+	typeBrowser := NewTypeBrowser(d, methods)
+	typeBrowser.SetSize(m.width, m.screenHeight())
+	m.typeBrowser = &typeBrowser
+	m.state = screenType
+}
+
+// refreshServices reloads the services list after descriptors have been
+// re-fetched, keeping the selected service if it is still there.
+func (m *Model) refreshServices() {
+	services, err := m.grpcClient.ListServices()
+	if err != nil {
+		return
+	}
+	selected, ok := m.servicesList.SelectedItem()
+	m.servicesList = NewServicesList(services)
+	m.servicesList.SetSize(m.width, m.screenHeight())
+	if ok {
+		m.servicesList.Select(selected.name)
+	}
+}
+
 func (m *Model) resize(msg tea.WindowSizeMsg) {
 	m.width = msg.Width
 	m.height = msg.Height
-	m.servicesList.SetSize(msg.Width, msg.Height)
+	m.servicesList.SetSize(msg.Width, m.screenHeight())
 	if m.methodsList != nil {
-		m.methodsList.SetSize(msg.Width, msg.Height)
+		m.methodsList.SetSize(msg.Width, m.screenHeight())
 	}
 	if m.callMethodForm != nil {
-		m.callMethodForm.SetSize(msg.Width, msg.Height)
+		m.callMethodForm.SetSize(msg.Width, m.screenHeight())
+	}
+	if m.typeBrowser != nil {
+		m.typeBrowser.SetSize(msg.Width, m.screenHeight())
 	}
+	if m.palette != nil {
+		m.palette.SetSize(msg.Width, m.screenHeight())
+	}
+}
+
+// screenHeight is the height left for the active screen below the status bar.
+func (m *Model) screenHeight() int {
+	return max(m.height-1, 0)
 }
 
 func (m *Model) forwardToScreen(msg tea.Msg) tea.Cmd {
@@ -165,6 +367,10 @@ func (m *Model) forwardToScreen(msg tea.Msg) tea.Cmd {
 			_, cmd := m.callMethodForm.Update(msg)
 			return cmd
 		}
+	case screenType:
+		if m.typeBrowser != nil {
+			return m.typeBrowser.Update(msg)
+		}
 	default:
 		panic("unknown state - non exhaustive switch for update")
 	}
@@ -172,6 +378,19 @@ func (m *Model) forwardToScreen(msg tea.Msg) tea.Cmd {
 }
 
 func (m Model) View() string {
+	screen := m.screenView()
+	if m.height > 0 {
+		// pin the status bar to the bottom of the terminal
+		screen = lipgloss.NewStyle().Height(m.screenHeight()).Render(screen)
+	}
+	return screen + "\n" + m.statusBar.View()
+}
+
+func (m Model) screenView() string {
+	if m.palette != nil {
+		return m.palette.View()
+	}
+
 	switch m.state {
 	case screenServices:
 		return m.servicesList.View()
@@ -185,6 +404,11 @@ func (m Model) View() string {
 			return m.callMethodForm.View()
 		}
 		return "No method details found"
+	case screenType:
+		if m.typeBrowser != nil {
+			return m.typeBrowser.View()
+		}
+		return "No type selected"
 	}
 	panic(fmt.Sprintf("unknown state - non exhaustive switch for screen state: %d", m.state))
 }
@@ -0,0 +1,379 @@
+package tui
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	echov1 "github.com/prnvbn/grpcexp/cmd/testserver/echo"
+	featurev1 "github.com/prnvbn/grpcexp/cmd/testserver/feature"
+	hellov1 "github.com/prnvbn/grpcexp/cmd/testserver/hello"
+	"github.com/prnvbn/grpcexp/cmd/testserver/server"
+	"github.com/prnvbn/grpcexp/internal/export"
+	"github.com/prnvbn/grpcexp/internal/grpc"
+	"github.com/prnvbn/grpcexp/internal/tui/call"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestNavigateServicesAndMethods(t *testing.T) {
+	h := newHarness(t)
+	h.waitFor("echo.v1.EchoService", "hello.v1.HelloService", "feature.v1.FeatureService")
+
+	h.press(tea.KeyEnter)
+	h.waitFor("EchoStream")
+
+	h.press(tea.KeyEnter)
+	h.waitFor("echo.v1.EchoService.Echo(", "[Submit]")
+
+	h.press(tea.KeyEsc)
+	h.waitFor("EchoStream")
+	h.press(tea.KeyEsc)
+	h.waitFor("helloworld.Greeter")
+	h.press(tea.KeyEsc)
+	h.waitForQuit()
+}
+
+func TestBackgroundRefreshUpdatesServices(t *testing.T) {
+	// cache the descriptors of a server that only has the greeter
+	cacheDir := t.TempDir()
+	greeter := grpclib.NewServer()
+	helloworldpb.RegisterGreeterServer(greeter, helloworldpb.UnimplementedGreeterServer{})
+	reflection.Register(greeter)
+	ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+	defer cancel()
+	stale, err := grpc.NewClient(ctx, grpc.Config{
+		Target:   "bufnet",
+		Dialer:   server.Listen(t, greeter),
+		Creds:    insecure.NewCredentials(),
+		CacheDir: cacheDir,
+	})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	select {
+	case <-stale.DescriptorsChanged():
+	case <-ctx.Done():
+		t.Fatal("descriptors were not cached")
+	}
+	_ = stale.Close()
+
+	// slow reflection down so the explorer starts from the cache
+	slowReflection := func(srv any, ss grpclib.ServerStream, info *grpclib.StreamServerInfo, handler grpclib.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, "/grpc.reflection.") {
+			time.Sleep(200 * time.Millisecond)
+		}
+		return handler(srv, ss)
+	}
+	h := newHarnessWithOptions(t, harnessOptions{
+		config: func(config *grpc.Config) { config.CacheDir = cacheDir },
+		server: []grpclib.ServerOption{grpclib.ChainStreamInterceptor(slowReflection)},
+	})
+	h.waitFor("helloworld.Greeter")
+	if strings.Contains(h.view(), "feature.v1.FeatureService") {
+		t.Fatal("services were not listed from the cache")
+	}
+	h.waitFor("echo.v1.EchoService", "feature.v1.FeatureService")
+}
+
+func TestUnaryCall(t *testing.T) {
+	h := newHarness(t)
+	h.open("Greeter.SayHello")
+	h.waitFor("helloworld.Greeter.SayHello(", "[Submit]")
+
+	h.typeText("Pranav")
+	h.press(tea.KeyEnter, tea.KeyEnter)
+	h.waitFor("Response", `"message": "Hello Pranav"`)
+
+	assertRequests(t, h.requests.All(), []invokedRequest{
+		{Method: "/helloworld.Greeter/SayHello", Message: map[string]any{"name": "Pranav"}},
+	})
+
+	h.press(tea.KeyEsc)
+	h.waitFor("SayHello")
+}
+
+func TestUnaryTemplate(t *testing.T) {
+	h := newHarnessWithOptions(t, harnessOptions{call: call.Options{Vars: map[string]any{"user": "Pranav"}}})
+	h.open("Greeter.SayHello")
+	h.waitFor("helloworld.Greeter.SayHello(", "[Submit]")
+
+	h.typeText("{{user}} {{randInt 7 7}}")
+	h.waitFor("→ Pranav 7")
+	h.press(tea.KeyEnter, tea.KeyEnter)
+	h.waitFor("Response", `"message": "Hello Pranav 7"`)
+
+	assertRequests(t, h.requests.All(), []invokedRequest{
+		{Method: "/helloworld.Greeter/SayHello", Message: map[string]any{"name": "Pranav 7"}},
+	})
+}
+
+// startAt restarts the model on the call screen of method, filled from the -d
+// of a grpcurl command.
+func (h *harness) startAt(method protoreflect.MethodDescriptor, command string) {
+	h.t.Helper()
+	args, err := export.SplitCommand(command)
+	if err != nil {
+		h.t.Fatalf("SplitCommand returned error: %v", err)
+	}
+	r, _, err := export.ParseGRPCURL(args)
+	if err != nil {
+		h.t.Fatalf("ParseGRPCURL returned error: %v", err)
+	}
+
+	m := h.model.(Model)
+	m.Open(method, r.Body)
+	h.model = m
+	h.run(m.Init())
+}
+
+func TestOpen(t *testing.T) {
+	h := newHarness(t)
+	method := helloworldpb.File_examples_helloworld_helloworld_helloworld_proto.Services().Get(0).Methods().ByName("SayHello")
+	h.startAt(method, `grpcurl -plaintext -d '{"name": "Pranav"}' localhost:50051 helloworld.Greeter/SayHello`)
+	h.waitFor("helloworld.Greeter.SayHello(", "name: Pranav", "[Submit]")
+
+	h.press(tea.KeyEnter, tea.KeyEnter)
+	h.waitFor("Response", `"message": "Hello Pranav"`)
+
+	assertRequests(t, h.requests.All(), []invokedRequest{
+		{Method: "/helloworld.Greeter/SayHello", Message: map[string]any{"name": "Pranav"}},
+	})
+}
+
+func TestOpenFillsBuilder(t *testing.T) {
+	h := newHarness(t)
+	method := echov1.File_cmd_testserver_echo_echo_proto.Services().Get(0).Methods().ByName("Echo")
+	h.startAt(method, `grpcurl -d '{
+  "message": "hi",
+  "boolean": true,
+  "enum": "ENUM_VALUE_2",
+  "int32_value": -1,
+  "int64Value": "9007199254740993",
+  "floatValue": 1.5,
+  "doubleValue": 2.25,
+  "strings": ["a", "b"],
+  "otherMessage": {"int32Value": 3},
+  "mapValue": {"k": "v"},
+  "oneofInt32Value": 7,
+  "timestamp": "2024-01-02T03:04:05Z",
+  "duration": "1.5s",
+  "unknown": 1
+}' localhost:50051 echo.v1.EchoService/Echo`)
+
+	h.waitFor(
+		"message: hi",
+		"int64_value: 9007199254740993",
+		"[0]: a", "[1]: b",
+		"int32_value: 3",
+		"key: k", "value: v",
+		"oneof_int32_value: 7",
+		"(not filled: unknown)",
+	)
+
+	// tab stops at the submit button once past the last field
+	for range 40 {
+		h.press(tea.KeyTab)
+	}
+	h.press(tea.KeyEnter)
+	h.waitFor("Response")
+
+	assertRequests(t, h.requests.All(), []invokedRequest{{
+		Method: "/echo.v1.EchoService/Echo",
+		Message: map[string]any{
+			"message":         "hi",
+			"boolean":         true,
+			"enum":            "ENUM_VALUE_2",
+			"int32Value":      -1.0,
+			"int64Value":      "9007199254740993",
+			"floatValue":      1.5,
+			"doubleValue":     2.25,
+			"strings":         []any{"a", "b"},
+			"otherMessage":    map[string]any{"int32Value": 3.0, "anotherMessage": map[string]any{}, "anotherMessage2": map[string]any{}},
+			"mapValue":        map[string]any{"k": "v"},
+			"nestedMessage":   map[string]any{},
+			"oneofInt32Value": 7.0,
+			"timestamp":       "2024-01-02T03:04:05Z",
+			"duration":        "1.500s",
+		},
+	}})
+}
+
+func TestOpenRecursiveRequest(t *testing.T) {
+	h := newHarness(t)
+	method := featurev1.File_cmd_testserver_feature_feature_proto.Services().Get(0).Methods().ByName("Tree")
+	h.startAt(method, `grpcurl -d '{"name": "a", "children": [{"name": "b", "children": [{"name": "c"}]}]}' localhost:50051 feature.v1.FeatureService/Tree`)
+	h.waitFor("name: a", "name: b", "name: c")
+
+	for range 20 {
+		h.press(tea.KeyTab)
+	}
+	h.press(tea.KeyEnter)
+	h.waitFor(`"nodes": 3`, `"depth": 3`)
+}
+
+func TestUnaryBench(t *testing.T) {
+	h := newHarness(t)
+	h.open("Greeter.SayHello")
+	h.waitFor("helloworld.Greeter.SayHello(", "[Submit]")
+
+	h.typeText("Pranav")
+	h.press(tea.KeyCtrlB)
+	h.waitFor("Bench", "> count:")
+
+	h.press(tea.KeyCtrlU)
+	h.typeText("20")
+	h.press(tea.KeyEnter, tea.KeyEnter, tea.KeyEnter, tea.KeyEnter)
+	h.waitFor("count:    20", "p99:", "OK: 20")
+
+	requests := h.requests.All()
+	if len(requests) != 20 || requests[0].Message["name"] != "Pranav" {
+		t.Fatalf("invoked requests = %v, want 20 requests for Pranav", requests)
+	}
+
+	h.press(tea.KeyEsc)
+	h.waitFor("[Submit]", "ctrl+b: bench")
+}
+
+func TestUnaryError(t *testing.T) {
+	h := newHarness(t)
+	h.open("FeatureService.Fail")
+	h.waitFor("feature.v1.FeatureService.Fail(", "[Submit]")
+
+	h.typeText("5")
+	h.press(tea.KeyTab)
+	h.typeText("nope")
+	h.press(tea.KeyEnter, tea.KeyEnter)
+	h.waitFor("Error", "RPC error: nope")
+
+	assertRequests(t, h.requests.All(), []invokedRequest{
+		{Method: "/feature.v1.FeatureService/Fail", Message: map[string]any{"code": float64(5), "message": "nope"}},
+	})
+}
+
+func TestBidiStream(t *testing.T) {
+	h := newHarness(t)
+	h.open("HelloService.HelloStream")
+	h.waitFor("hello.v1.HelloService.HelloStream(", "No stream events yet.")
+
+	h.typeText("Pranav")
+	h.press(tea.KeyTab, tea.KeyEnter)
+	h.waitFor("Hello Pranav")
+
+	h.press(tea.KeyUp, tea.KeyCtrlU)
+	h.typeText("Manya")
+	h.press(tea.KeyTab, tea.KeyEnter)
+	h.waitFor("Hello Manya")
+
+	assertRequests(t, h.requests.All(), []invokedRequest{
+		{Method: "/hello.v1.HelloService/HelloStream", Message: map[string]any{"name": "Pranav"}},
+		{Method: "/hello.v1.HelloService/HelloStream", Message: map[string]any{"name": "Manya"}},
+	})
+}
+
+func TestStreamRecordsSentHeaders(t *testing.T) {
+	h := newHarnessWithOptions(t, harnessOptions{
+		config: func(config *grpc.Config) { config.Headers = []string{"x-user: pranav"} },
+	})
+	h.open("HelloService.HelloStream")
+	h.waitFor("hello.v1.HelloService.HelloStream(", "No stream events yet.")
+
+	h.typeText("Pranav")
+	h.press(tea.KeyTab, tea.KeyEnter)
+	h.waitFor("> headers", "x-user: pranav", "Hello Pranav")
+}
+
+func TestRepeatStopsWhenServerEndsStream(t *testing.T) {
+	// the server ends the stream after three messages and stops reading
+	endEarly := func(srv any, ss grpclib.ServerStream, info *grpclib.StreamServerInfo, handler grpclib.StreamHandler) error {
+		if info.FullMethod != "/hello.v1.HelloService/HelloStream" {
+			return handler(srv, ss)
+		}
+		for range 3 {
+			if err := ss.RecvMsg(new(hellov1.HelloRequest)); err != nil {
+				return err
+			}
+		}
+		return status.Error(codes.ResourceExhausted, "enough")
+	}
+	h := newHarnessWithOptions(t, harnessOptions{
+		call:   call.Options{TranscriptLimit: 100},
+		server: []grpclib.ServerOption{grpclib.ChainStreamInterceptor(endEarly)},
+	})
+	h.open("HelloService.HelloStream")
+	h.waitFor("hello.v1.HelloService.HelloStream(", "No stream events yet.")
+
+	h.typeText("Pranav")
+	h.press(tea.KeyCtrlN)
+	h.waitFor("Repeat payload")
+	h.press(tea.KeyCtrlU)
+	h.typeText("1000")
+	h.press(tea.KeyEnter, tea.KeyCtrlU)
+	h.typeText("0s")
+	h.press(tea.KeyEnter)
+
+	h.waitFor("RPC error: enough", "stopped", "/1000 messages from builder", "status: closed")
+	if strings.Contains(h.view(), "esc: stop") {
+		t.Fatal("the repeat is still running after the stream ended")
+	}
+	if requests := h.requests.All(); len(requests) != 3 {
+		t.Fatalf("server read %d requests, want 3", len(requests))
+	}
+}
+
+func TestExportPicker(t *testing.T) {
+	h := newHarness(t)
+	h.open("HelloService.HelloStream")
+	h.waitFor("hello.v1.HelloService.HelloStream(", "No stream events yet.")
+
+	h.press(tea.KeyCtrlY)
+	h.waitFor("Copy request as", "> grpcurl", "buf curl", "grpc_cli", "go", "python")
+
+	h.press(tea.KeyDown, tea.KeyDown, tea.KeyDown, tea.KeyEnter)
+	h.waitFor("> connect curl", "connect curl only supports unary methods")
+
+	h.press(tea.KeyEsc)
+	h.waitUntil("picker closed", func(view string) bool {
+		return !strings.Contains(view, "Copy request as") && strings.Contains(view, "[Send]")
+	})
+}
+
+func TestServerStream(t *testing.T) {
+	h := newHarness(t)
+	h.open("FeatureService.Ticker")
+	h.waitFor("feature.v1.FeatureService.Ticker(")
+
+	h.typeText("3")
+	h.press(tea.KeyTab)
+	h.typeText("10ms")
+	h.press(tea.KeyTab, tea.KeyEnter)
+	h.waitFor(`"seq": 3`, "OK")
+
+	assertRequests(t, h.requests.All(), []invokedRequest{
+		{Method: "/feature.v1.FeatureService/Ticker", Message: map[string]any{"count": float64(3), "interval": "0.010s"}},
+	})
+}
+
+func TestTypeBrowser(t *testing.T) {
+	h := newHarness(t)
+	h.open("feature.v1.TreeNode")
+	h.waitFor("message TreeNode", "children repeated feature.v1.TreeNode", "feature.v1.FeatureService.Tree")
+
+	h.press(tea.KeyEsc)
+	h.waitFor("echo.v1.EchoService")
+}
+
+func assertRequests(t *testing.T, got, want []invokedRequest) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invoked requests = %v, want %v", got, want)
+	}
+}
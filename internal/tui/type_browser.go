@@ -0,0 +1,346 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	typeCommentStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("243")).Italic(true)
+	typeNumberStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	typeRefStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("75"))
+)
+
+// TypeBrowser renders a message or enum as a tree. Message fields can be
+// expanded in place, referenced types opened, and the methods that use the
+// type are listed at the bottom.
+type TypeBrowser struct {
+	// stack holds the types navigated through, the last one is shown
+	stack    []protoreflect.Descriptor
+	methods  []protoreflect.MethodDescriptor
+	expanded map[string]bool
+	rows     []typeRow
+	cursor   int
+	offset   int
+	height   int
+}
+
+type typeRow struct {
+	text string
+	// path identifies an expandable field for the expanded set
+	path string
+	// target is the type or method opened by enter
+	target protoreflect.Descriptor
+}
+
+// openMethodMsg asks the model to open the call screen for a method, with the
+// builder filled from request if it is set.
+type openMethodMsg struct {
+	method  protoreflect.MethodDescriptor
+	request map[string]any
+}
+
+// NewTypeBrowser shows d, which must be a message or enum. methods are
+// searched for the "used by" list.
+func NewTypeBrowser(d protoreflect.Descriptor, methods []protoreflect.MethodDescriptor) TypeBrowser {
+	b := TypeBrowser{
+		stack:   []protoreflect.Descriptor{d},
+		methods: methods,
+	}
+	b.reset()
+	return b
+}
+
+func (b *TypeBrowser) SetSize(_, height int) {
+	b.height = height
+	b.scrollToCursor()
+}
+
+// Back returns to the previously viewed type. It reports false when there is
+// nothing to go back to.
+func (b *TypeBrowser) Back() bool {
+	if len(b.stack) <= 1 {
+		return false
+	}
+	b.stack = b.stack[:len(b.stack)-1]
+	b.reset()
+	return true
+}
+
+func (b *TypeBrowser) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "shift+tab":
+		if b.cursor > 0 {
+			b.cursor--
+		}
+	case "down", "tab":
+		if b.cursor < len(b.rows)-1 {
+			b.cursor++
+		}
+	case "right":
+		if row := b.rows[b.cursor]; row.path != "" && !b.expanded[row.path] {
+			b.expanded[row.path] = true
+			b.render()
+		}
+	case "left":
+		if row := b.rows[b.cursor]; row.path != "" && b.expanded[row.path] {
+			delete(b.expanded, row.path)
+			b.render()
+		}
+	case "enter":
+		switch target := b.rows[b.cursor].target.(type) {
+		case protoreflect.MethodDescriptor:
+			return func() tea.Msg { return openMethodMsg{method: target} }
+		case protoreflect.MessageDescriptor, protoreflect.EnumDescriptor:
+			b.stack = append(b.stack, target)
+			b.reset()
+		}
+	}
+
+	b.scrollToCursor()
+	return nil
+}
+
+func (b *TypeBrowser) View() string {
+	var out strings.Builder
+
+	names := make([]string, len(b.stack))
+	for i, d := range b.stack {
+		names[i] = string(d.Name())
+	}
+	out.WriteString(selectedStyle.Render(string(b.current().FullName())))
+	if len(b.stack) > 1 {
+		out.WriteString(statusTextStyle.Render("  (" + strings.Join(names, " > ") + ")"))
+	}
+	out.WriteString("\n\n")
+
+	end := min(b.offset+b.visibleRows(), len(b.rows))
+	for i := b.offset; i < end; i++ {
+		if i == b.cursor {
+			out.WriteString("> ")
+		} else {
+			out.WriteString("  ")
+		}
+		out.WriteString(b.rows[i].text)
+		out.WriteString("\n")
+	}
+
+	out.WriteString("\n")
+	out.WriteString(statusTextStyle.Render("↑/↓: move • →/←: expand/collapse • enter: open • esc: back"))
+	return out.String()
+}
+
+func (b *TypeBrowser) current() protoreflect.Descriptor {
+	return b.stack[len(b.stack)-1]
+}
+
+func (b *TypeBrowser) reset() {
+	b.expanded = make(map[string]bool)
+	b.cursor = 0
+	b.offset = 0
+	b.render()
+}
+
+func (b *TypeBrowser) render() {
+	b.rows = b.rows[:0]
+
+	switch d := b.current().(type) {
+	case protoreflect.MessageDescriptor:
+		b.addComments(d, 0)
+		b.rows = append(b.rows, typeRow{text: "message " + string(d.Name())})
+		b.addFields(d, "", 1)
+	case protoreflect.EnumDescriptor:
+		b.addComments(d, 0)
+		b.rows = append(b.rows, typeRow{text: "enum " + string(d.Name())})
+		values := d.Values()
+		for i := 0; i < values.Len(); i++ {
+			v := values.Get(i)
+			b.addComments(v, 1)
+			text := fmt.Sprintf("  %s %s%s", typeNumberStyle.Render(fmt.Sprintf("%3d", v.Number())), v.Name(), formatOptions(v.Options()))
+			b.rows = append(b.rows, typeRow{text: text})
+		}
+	}
+
+	b.rows = append(b.rows, typeRow{text: ""})
+	users := b.usedBy()
+	if len(users) == 0 {
+		b.rows = append(b.rows, typeRow{text: statusTextStyle.Render("not used by any method")})
+	} else {
+		b.rows = append(b.rows, typeRow{text: "used by:"})
+		for _, m := range users {
+			b.rows = append(b.rows, typeRow{text: "  " + typeRefStyle.Render(string(m.FullName())), target: m})
+		}
+	}
+
+	b.cursor = min(b.cursor, len(b.rows)-1)
+}
+
+func (b *TypeBrowser) addFields(msg protoreflect.MessageDescriptor, path string, depth int) {
+	indent := strings.Repeat("  ", depth)
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldPath := path + "." + string(field.Name())
+
+		b.addComments(field, depth)
+
+		row := typeRow{target: fieldType(field)}
+		expandable := field.Message() != nil && !field.IsMap()
+		marker := "  "
+		if expandable {
+			row.path = fieldPath
+			marker = "+ "
+			if b.expanded[fieldPath] {
+				marker = "- "
+			}
+		}
+
+		var extra []string
+		if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			extra = append(extra, "oneof "+string(oneof.Name()))
+		}
+		suffix := ""
+		if len(extra) > 0 {
+			suffix = " " + statusTextStyle.Render("("+strings.Join(extra, ", ")+")")
+		}
+
+		row.text = fmt.Sprintf("%s%s%s %s %s%s%s",
+			indent,
+			marker,
+			typeNumberStyle.Render(fmt.Sprintf("%3d", field.Number())),
+			field.Name(),
+			fieldTypeName(field),
+			formatOptions(field.Options()),
+			suffix,
+		)
+		b.rows = append(b.rows, row)
+
+		if expandable && b.expanded[fieldPath] {
+			b.addFields(field.Message(), fieldPath, depth+1)
+		}
+	}
+}
+
+func (b *TypeBrowser) addComments(d protoreflect.Descriptor, depth int) {
+	loc := d.ParentFile().SourceLocations().ByDescriptor(d)
+	comment := strings.TrimSpace(loc.LeadingComments)
+	if comment == "" {
+		comment = strings.TrimSpace(loc.TrailingComments)
+	}
+	if comment == "" {
+		return
+	}
+
+	indent := strings.Repeat("  ", depth)
+	for _, line := range strings.Split(comment, "\n") {
+		b.rows = append(b.rows, typeRow{text: indent + "  " + typeCommentStyle.Render("// "+strings.TrimSpace(line))})
+	}
+}
+
+// usedBy returns the methods whose input or output contains the current type.
+func (b *TypeBrowser) usedBy() []protoreflect.MethodDescriptor {
+	name := b.current().FullName()
+	var users []protoreflect.MethodDescriptor
+	for _, m := range b.methods {
+		if containsType(m.Input(), name, map[protoreflect.FullName]bool{}) ||
+			containsType(m.Output(), name, map[protoreflect.FullName]bool{}) {
+			users = append(users, m)
+		}
+	}
+	return users
+}
+
+func (b *TypeBrowser) visibleRows() int {
+	return max(b.height-4, 1)
+}
+
+func (b *TypeBrowser) scrollToCursor() {
+	visible := b.visibleRows()
+	switch {
+	case b.cursor < b.offset:
+		b.offset = b.cursor
+	case b.cursor >= b.offset+visible:
+		b.offset = b.cursor - visible + 1
+	}
+}
+
+// containsType reports whether msg is, or transitively has a field of, the named type.
+func containsType(msg protoreflect.MessageDescriptor, name protoreflect.FullName, seen map[protoreflect.FullName]bool) bool {
+	if msg.FullName() == name {
+		return true
+	}
+	if seen[msg.FullName()] {
+		return false
+	}
+	seen[msg.FullName()] = true
+
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if e := field.Enum(); e != nil && e.FullName() == name {
+			return true
+		}
+		if m := field.Message(); m != nil && containsType(m, name, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldType returns the message or enum a field refers to, skipping map entries.
+func fieldType(field protoreflect.FieldDescriptor) protoreflect.Descriptor {
+	if field.IsMap() {
+		field = field.MapValue()
+	}
+	if m := field.Message(); m != nil {
+		return m
+	}
+	if e := field.Enum(); e != nil {
+		return e
+	}
+	return nil
+}
+
+func fieldTypeName(field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", scalarOrRef(field.MapKey()), scalarOrRef(field.MapValue()))
+	}
+	name := scalarOrRef(field)
+	if field.IsList() {
+		return "repeated " + name
+	}
+	return name
+}
+
+func scalarOrRef(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.Message() != nil:
+		return typeRefStyle.Render(string(field.Message().FullName()))
+	case field.Enum() != nil:
+		return typeRefStyle.Render(string(field.Enum().FullName()))
+	default:
+		return field.Kind().String()
+	}
+}
+
+func formatOptions(opts proto.Message) string {
+	if opts == nil || !opts.ProtoReflect().IsValid() {
+		return ""
+	}
+	text := strings.TrimSpace(prototext.MarshalOptions{}.Format(opts))
+	if text == "" {
+		return ""
+	}
+	return " " + statusTextStyle.Render("["+text+"]")
+}